@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Names of the entries in a backup archive
+const (
+	backupStatusName  = "read-status.json"
+	backupArchiveName = "archive.json"
+	backupConfigName  = "informantrc.json"
+)
+
+var (
+	restoreForce bool
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup <file.tar.gz>",
+	Short: "Back up read status, stars and config",
+	Long: `Write the read status (including starred items), the offline item archive
+and the config file in use to a gzipped tar file, which 'informant restore'
+can bring back after reinstalling or on another machine.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadConfig(); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		status, err := store.ExportStatus()
+		if err != nil {
+			return err
+		}
+		archive, err := store.ExportArchive()
+		if err != nil {
+			return err
+		}
+
+		entries := map[string][]byte{
+			backupStatusName:  status,
+			backupArchiveName: archive,
+		}
+		if configLoaded {
+			data, err := os.ReadFile(viper.ConfigFileUsed())
+			if err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+			entries[backupConfigName] = data
+		}
+
+		if err := writeBackup(args[0], entries); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+
+		fmt.Printf("Backed up state to %s\n", args[0])
+		return nil
+	},
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file.tar.gz>",
+	Short: "Restore read status, stars and config from a backup",
+	Long: `Restore a backup written by 'informant backup'. The read status replaces
+the current one and archived items are added to the offline archive.
+
+The config file is restored to the config file in use, or to
+$HOME/.informantrc.json when there is none. An existing config file is only
+overwritten with --force.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := readBackup(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		// Restore the config first so storage is opened with its settings
+		if data, ok := entries[backupConfigName]; ok {
+			if err := restoreConfig(data); err != nil {
+				return err
+			}
+		}
+
+		if _, err := loadConfig(); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		if data, ok := entries[backupStatusName]; ok {
+			if err := store.RestoreStatus(data); err != nil {
+				return fmt.Errorf("failed to restore read status: %w", err)
+			}
+			fmt.Println("Restored read status.")
+		}
+
+		if data, ok := entries[backupArchiveName]; ok {
+			if err := store.RestoreArchive(data); err != nil {
+				return fmt.Errorf("failed to restore item archive: %w", err)
+			}
+			fmt.Println("Restored item archive.")
+		}
+
+		return nil
+	},
+}
+
+// restoreConfig writes a backed up config file, refusing to overwrite an
+// existing one without --force
+func restoreConfig(data []byte) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, ".informantrc.json")
+	}
+
+	if _, err := os.Stat(path); err == nil && !restoreForce {
+		fmt.Printf("Skipping config: %s already exists (use --force to overwrite).\n", path)
+		return nil
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read restored config: %w", err)
+	}
+	configLoaded = true
+
+	fmt.Printf("Restored config to %s\n", path)
+	return nil
+}
+
+// writeBackup writes entries as a gzipped tar file
+func writeBackup(path string, entries map[string][]byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+	for _, name := range []string{backupStatusName, backupArchiveName, backupConfigName} {
+		data, ok := entries[name]
+		if !ok {
+			continue
+		}
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// readBackup reads the known entries of a gzipped tar file
+func readBackup(path string) (map[string][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.Name {
+		case backupStatusName, backupArchiveName, backupConfigName:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			entries[header.Name] = data
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s is not an informant backup", path)
+	}
+	return entries, nil
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().BoolVar(&restoreForce, "force", false, "overwrite an existing config file")
+}
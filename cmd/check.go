@@ -1,81 +1,419 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"informant/internal/config"
 	"informant/internal/feed"
+	"informant/internal/i18n"
+	"informant/internal/logging"
+	"informant/internal/platform"
 	"informant/internal/storage"
+	"informant/internal/tui"
 	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+// checkGracePeriod is how long check may keep waiting on the read status
+// past its deadline, to record what it fetched, before it gives up
+const checkGracePeriod = 2 * time.Second
+
+// checkFetchLockWait is how long check waits for another run fetching the
+// feeds before it fetches them itself
+const checkFetchLockWait = 5 * time.Second
+
+var (
+	checkTargets       string
+	checkOnlyIfUpdates bool
+	checkShowAll       bool
+	checkReview        bool
+)
+
 // checkCmd represents the check command
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check for unread news items",
 	Long: `Check for any unread news items. If there is only one unread item it will
-print it and mark it as read. The command will exit with return code equal to
-the number of unread news items.
+print it and mark it as read. With several unread items, --show-all prints the
+title, date and summary of each; this is the default when stdout is a
+terminal. The command will exit with return code equal to the number of
+unread news items, or with 1 when any items are unread if --fail-on-unread
+(or "check-fail-on-unread" in the config) is set.
 
 This is the command used by the pacman hook to interrupt transactions when
-there are unread news items.`,
+there are unread news items. With "check-highlighted-only" enabled in the
+config, only unread items matching a highlight rule are counted.
+
+With --min-severity (or "check-min-severity" in the config), only unread
+items at or above that severity (info, warning or critical) are counted.
+Less severe unread items are listed in a one-line notice each.
+
+With --targets, only unread items whose title or content mentions one of the
+given packages are counted. The pacman hook installed with
+'informant install --package-aware' passes the transaction's packages on stdin
+using --targets -.
+
+The whole run has an overall deadline (--timeout, or "check-timeout" in the
+config). Feeds that cannot be fetched in time are skipped with a warning naming
+them, unless the failure policy is "closed", in which case check exits with 1.
+Should check still be waiting shortly after the deadline, for example on the
+lock of the read status, it gives up and exits as the failure policy says.
+Files being written are always written completely first.
+
+Only one check fetches the feeds at a time: a check started while another one,
+or watch, is fetching waits for it and reuses the feeds it cached instead of
+fetching them again. It waits a few seconds at most, before its deadline
+starts, and then fetches the feeds itself.
+
+With --only-if-updates, the check succeeds immediately when checkupdates
+reports no pending upgrades, which suits cron-driven checks.
+
+With --review, unread items are shown one by one on the controlling terminal
+to be read and acknowledged, and check only fails for items left unread. This
+is what the hook installed with 'informant install --interactive' runs. Without
+a terminal, check behaves as if --review was not given.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		store, err := storage.NewWithConfirmation(!viper.GetBool("no-confirm"))
+		err = runCheck(cmd, cfg)
+		if errors.Is(err, context.DeadlineExceeded) {
+			logging.Errorf("check did not finish within %s, giving up: %v", cfg.CheckTimeout, err)
+			if cfg.CheckFailurePolicy == config.FailClosed {
+				exit(1)
+			}
+			exit(0)
+		}
+		return err
+	},
+}
+
+// runCheck runs check once the config is loaded. It returns an error
+// wrapping context.DeadlineExceeded when it gave up waiting past the deadline.
+func runCheck(cmd *cobra.Command, cfg *config.Config) error {
+	// Idle systems have nothing to upgrade, so news can wait
+	if checkOnlyIfUpdates {
+		updatesCtx, updatesCancel := context.WithTimeout(context.Background(), cfg.CheckTimeout)
+		pending, err := hasPendingUpdates(updatesCtx)
+		updatesCancel()
 		if err != nil {
-			return fmt.Errorf("failed to initialize storage: %w", err)
+			logging.Warnf("Failed to check for updates, checking news anyway: %v", err)
+		} else if !pending {
+			logging.Infof("No pending updates, skipping news check")
+			return nil
 		}
+	}
 
-		var unreadCount int
-		var unreadItems []feed.Item
+	store, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
 
-		for _, feedCfg := range cfg.Feeds {
-			items, err := feed.ParseFeedWithStorage(feedCfg.URL, store)
-			if err != nil {
-				if viper.GetBool("verbose") {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to parse feed %s: %v\n", feedCfg.Name, err)
-				}
-				continue
+	var unreadCount int
+	var unreadItems []feed.Item
+
+	// Held until the notifications are recorded, so a parallel run
+	// neither fetches again nor notifies twice. The wait has a bound of its
+	// own, so that the deadline is left to fetching.
+	lockCtx, lockCancel := context.WithTimeout(context.Background(), checkFetchLockWait)
+	unlock := lockFetch(lockCtx, store)
+	lockCancel()
+	defer unlock()
+
+	// Bound the run so an unreachable server or a stuck lock cannot wedge
+	// pacman. Fetches are cancelled at the deadline; waiting on the read
+	// status is given a little longer, to record what was fetched.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CheckTimeout)
+	defer cancel()
+	storeCtx, storeCancel := context.WithTimeout(context.Background(), cfg.CheckTimeout+checkGracePeriod)
+	defer storeCancel()
+	store.SetContext(storeCtx)
+
+	items, failed := fetchItems(ctx, cfg, store, progressEnabled())
+	if len(failed) > 0 {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			logging.Warnf("Deadline of %s reached, skipped: %s", cfg.CheckTimeout, strings.Join(failed, ", "))
+		}
+		if cfg.CheckFailurePolicy == config.FailClosed {
+			logging.Errorf("%d of %d feeds could not be fetched (check-failure-policy is %q)",
+				len(failed), len(cfg.Feeds), config.FailClosed)
+			exit(1)
+		}
+		logging.Warnf("%d of %d feeds could not be fetched, continuing without them",
+			len(failed), len(cfg.Feeds))
+	}
+
+	for _, item := range items {
+		if isPending(store, item) {
+			unreadItems = append(unreadItems, item)
+			unreadCount++
+		}
+	}
+
+	// Push notifications for items not seen by a previous run
+	notifyNewItems(cfg, store, unreadItems)
+	unlock()
+
+	// Optionally only interrupt for items matching a highlight rule
+	if cfg.CheckHighlightedOnly {
+		var highlighted []feed.Item
+		for _, item := range unreadItems {
+			if item.Highlighted {
+				highlighted = append(highlighted, item)
 			}
+		}
+		unreadItems = highlighted
+		unreadCount = len(highlighted)
+	}
 
-			for _, item := range items {
-				if !store.IsRead(item.ID) {
-					unreadItems = append(unreadItems, item)
-					unreadCount++
-				}
+	// Only block for items at or above the configured severity; the
+	// others are mentioned without failing the check
+	if cfg.CheckMinSeverity != "" {
+		minRank := config.SeverityRank(cfg.CheckMinSeverity)
+		var blocking []feed.Item
+		for _, item := range unreadItems {
+			if config.SeverityRank(item.Severity) >= minRank {
+				blocking = append(blocking, item)
+			} else {
+				fmt.Println(i18n.T("Unread %s news: %s", item.Severity, item.Title))
 			}
 		}
+		unreadItems = blocking
+		unreadCount = len(blocking)
+	}
+
+	// When the hook passes the transaction's packages, only interrupt for
+	// items that mention one of them
+	if checkTargets != "" {
+		targets, err := readTargets(checkTargets)
+		if err != nil {
+			return fmt.Errorf("failed to read targets: %w", err)
+		}
 
-		// If there's exactly one unread item, print it and mark as read
-		if unreadCount == 1 {
-			item := unreadItems[0]
-			fmt.Printf("Title: %s\n", item.Title)
-			fmt.Printf("Date: %s\n", item.Published.Format("2006-01-02 15:04:05"))
-			if item.FeedName != "" {
-				fmt.Printf("Feed: %s\n", item.FeedName)
+		matchers := packageMatchers(targets)
+		var relevant []feed.Item
+		for _, item := range unreadItems {
+			if mentionsPackage(item, matchers) {
+				relevant = append(relevant, item)
 			}
-			fmt.Printf("\n%s\n", item.Content)
+		}
+		unreadItems = relevant
+		unreadCount = len(relevant)
+	}
 
-			if err := store.MarkAsRead(item.ID); err != nil {
-				return fmt.Errorf("failed to mark item as read: %w", err)
+	// Let the user read the news on the terminal instead of failing outright
+	if checkReview && unreadCount > 0 {
+		if tty, err := openReviewTerminal(); err != nil {
+			logging.Infof("Not reviewing news: %v", err)
+		} else {
+			defer tty.Close()
+			// Reading takes as long as it takes
+			store.SetContext(context.Background())
+			if unreadCount, err = reviewItems(tty, unreadItems, store); err != nil {
+				return err
 			}
-		} else if unreadCount > 1 {
-			fmt.Printf("There are %d unread news items.\n", unreadCount)
-			fmt.Println("Use 'informant list --unread' to see them or 'informant read' to read them.")
+			unreadItems = nil
 		}
+	}
 
-		// Exit with the number of unread items for pacman hook integration
-		os.Exit(unreadCount)
-		return nil
-	},
+	// If there's exactly one unread item, print it and mark as read
+	if len(unreadItems) == 1 {
+		item := unreadItems[0]
+		fmt.Printf("%s %s\n", i18n.T("Title:"), item.Title)
+		fmt.Printf("%s %s\n", i18n.T("Date:"), tui.FormatTime(item.Published, "2006-01-02 15:04:05"))
+		if item.FeedName != "" {
+			fmt.Printf("%s %s\n", i18n.T("Feed:"), item.FeedName)
+		}
+		fmt.Printf("\n%s\n", item.Content)
+
+		if err := store.MarkAsRead(item.Key()); err != nil {
+			return fmt.Errorf("failed to mark item as read: %w", err)
+		}
+	} else if len(unreadItems) > 1 {
+		// Print every unread item when asked to, or by default on a terminal
+		showAll := checkShowAll
+		if !cmd.Flags().Changed("show-all") {
+			showAll = isTerminal(os.Stdout) && !isPlain()
+		}
+		if showAll {
+			for _, item := range unreadItems {
+				fmt.Printf("* %s\n", item.Title)
+				fmt.Printf("  %s %s", i18n.T("Date:"), tui.FormatTime(item.Published, "2006-01-02 15:04:05"))
+				if item.FeedName != "" {
+					fmt.Printf(" | %s %s", i18n.T("Feed:"), item.FeedName)
+				}
+				fmt.Println()
+				if summary := summarize(item.Content, 200); summary != "" {
+					fmt.Printf("  %s\n", summary)
+				}
+				fmt.Println()
+			}
+		}
+
+		fmt.Println(i18n.T("There are %d unread news items.", unreadCount))
+		fmt.Println(i18n.T("Use 'informant list --unread' to see them or 'informant read' to read them."))
+	}
+
+	// Exit with 1 when anything is unread if boolean semantics were requested
+	if cfg.CheckFailOnUnread {
+		if unreadCount > 0 {
+			exit(1)
+		}
+		exit(0)
+	}
+
+	// Exit with the number of unread items for pacman hook integration
+	exit(unreadCount)
+	return nil
+}
+
+// hasPendingUpdates reports whether any package upgrades are pending. It uses
+// checkupdates from pacman-contrib, which syncs a temporary database, and
+// falls back to pacman -Qu against the local sync database.
+func hasPendingUpdates(ctx context.Context) (bool, error) {
+	if !platform.HasPacman() {
+		return false, platform.ErrNoPacman
+	}
+
+	if path, err := exec.LookPath("checkupdates"); err == nil {
+		err := exec.CommandContext(ctx, path).Run()
+		if err == nil {
+			return true, nil
+		}
+		// checkupdates exits with 2 when there are no updates
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+			return false, nil
+		}
+		return false, fmt.Errorf("checkupdates failed: %w", err)
+	}
+
+	output, err := exec.CommandContext(ctx, "pacman", "-Qu").Output()
+	if err != nil {
+		// pacman -Qu exits with 1 when there are no updates
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(output) == 0 {
+			return false, nil
+		}
+		return false, fmt.Errorf("pacman -Qu failed: %w", err)
+	}
+
+	return len(strings.TrimSpace(string(output))) > 0, nil
+}
+
+// openReviewTerminal opens the controlling terminal for --review. pacman
+// passes hook output through a pipe, so stdin and stdout cannot be used.
+func openReviewTerminal() (*os.File, error) {
+	if isPlain() {
+		return nil, fmt.Errorf("plain mode never prompts")
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("no controlling terminal: %w", err)
+	}
+	return tty, nil
+}
+
+// reviewItems walks through the items on tty like 'informant read' and
+// returns how many of them are still unread afterwards
+func reviewItems(tty *os.File, items []feed.Item, store *storage.Storage) (int, error) {
+	// Prompts, the pager and styling all go through stdin and stdout
+	stdin, stdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = tty, tty
+	defer func() { os.Stdin, os.Stdout = stdin, stdout }()
+
+	fmt.Println(i18n.T("There are %d unread news items.", len(items)))
+	fmt.Println()
+	if err := readUnreadInteractive(items, store); err != nil {
+		return 0, err
+	}
+
+	unread := 0
+	for _, item := range items {
+		if !store.IsRead(item.Key()) {
+			unread++
+		}
+	}
+	if unread > 0 {
+		fmt.Println(i18n.T("%d news items left unread.", unread))
+	}
+	return unread, nil
+}
+
+// readTargets returns the package names given to --targets. "-" reads one
+// name per line from stdin, as passed by a pacman hook with NeedsTargets;
+// anything else is a comma-separated list.
+func readTargets(spec string) ([]string, error) {
+	var names []string
+	if spec == "-" {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			names = append(names, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	} else {
+		names = strings.Split(spec, ",")
+	}
+
+	var targets []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			targets = append(targets, name)
+		}
+	}
+	return targets, nil
+}
+
+// packageMatchers compiles the patterns finding the packages as whole words,
+// once for all items
+func packageMatchers(packages []string) []*regexp.Regexp {
+	matchers := make([]*regexp.Regexp, len(packages))
+	for i, pkg := range packages {
+		// Package names consist of these characters, so they delimit a name
+		matchers[i] = regexp.MustCompile(`(?i)(^|[^a-z0-9@._+-])` + regexp.QuoteMeta(pkg) + `($|[^a-z0-9@._+-])`)
+	}
+	return matchers
+}
+
+// mentionsPackage reports whether an item's title or content mentions one of
+// the packages matched by packageMatchers
+func mentionsPackage(item feed.Item, matchers []*regexp.Regexp) bool {
+	for _, re := range matchers {
+		if re.MatchString(item.Title) || re.MatchString(item.Content) {
+			return true
+		}
+	}
+	return false
 }
 
 func init() {
 	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().Duration("timeout", config.DefaultCheckTimeout, "overall deadline for the run; feeds not fetched by then are skipped")
+	checkCmd.Flags().String("failure-policy", config.FailOpen, "what to do when feeds cannot be fetched: open (continue) or closed (fail)")
+	viper.BindPFlag("check-timeout", checkCmd.Flags().Lookup("timeout"))
+	viper.BindPFlag("check-failure-policy", checkCmd.Flags().Lookup("failure-policy"))
+
+	checkCmd.Flags().String("min-severity", "", "only count unread items of at least this severity: info, warning or critical")
+	viper.BindPFlag("check-min-severity", checkCmd.Flags().Lookup("min-severity"))
+
+	checkCmd.Flags().Bool("fail-on-unread", false, "exit with 1 when any items are unread instead of the unread count")
+	viper.BindPFlag("check-fail-on-unread", checkCmd.Flags().Lookup("fail-on-unread"))
+
+	checkCmd.Flags().BoolVar(&checkShowAll, "show-all", false, "print every unread item when there are several (default when stdout is a terminal)")
+	checkCmd.Flags().BoolVar(&checkOnlyIfUpdates, "only-if-updates", false, "succeed without checking news when no package upgrades are pending")
+	checkCmd.Flags().BoolVar(&checkReview, "review", false, "read and acknowledge unread items on the controlling terminal")
+	checkCmd.Flags().StringVar(&checkTargets, "targets", "", "only count items mentioning these packages (comma-separated, or - to read from stdin)")
 }
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// countCmd represents the count command
+var countCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Print the number of unread news items",
+	Long: `Print the number of unread news items. Unlike 'check', this never marks
+items as read and always exits with status 0, which makes it suitable for
+status bars and scripts.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		unreadCount := 0
+		for _, item := range loadItems(cfg, store) {
+			if isPending(store, item) {
+				unreadCount++
+			}
+		}
+
+		fmt.Println(unreadCount)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(countCmd)
+}
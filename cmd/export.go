@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"informant/internal/feed"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDir    string
+	exportFormat string
+	exportUnread bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export news items to Markdown or HTML files",
+	Long: `Write each news item, including archived ones, to a standalone Markdown or
+HTML file in --dir, for archiving advisories into a wiki or static site.
+
+Every file starts with YAML front matter holding the title, date, link and
+feed. Files are named after the item's date and title, such as
+2024-01-02-grub-needs-manual-intervention.md, and are overwritten when
+exporting again. Items are not marked as read.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportFormat != "md" && exportFormat != "html" {
+			return fmt.Errorf("invalid --format %q: must be md or html", exportFormat)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		allItems := withArchived(cfg, store, loadItems(cfg, store))
+		sortByPublished(allItems, false)
+
+		if err := os.MkdirAll(exportDir, 0755); err != nil {
+			return fmt.Errorf("failed to create export directory: %w", err)
+		}
+
+		used := make(map[string]bool)
+		count := 0
+		for _, item := range allItems {
+			if exportUnread && store.IsRead(item.Key()) {
+				continue
+			}
+
+			name := exportFileName(item, exportFormat, used)
+			var content string
+			if exportFormat == "html" {
+				content = exportHTML(item)
+			} else {
+				content = exportMarkdown(item)
+			}
+
+			if err := os.WriteFile(filepath.Join(exportDir, name), []byte(content), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", name, err)
+			}
+			count++
+		}
+
+		fmt.Printf("Exported %d items to %s\n", count, exportDir)
+		return nil
+	},
+}
+
+// slugPattern matches the runs of characters replaced by "-" in file names
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportFileName names an item's file after its date and title, adding a
+// number when an earlier item of the same export took the name
+func exportFileName(item feed.Item, ext string, used map[string]bool) string {
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(item.Title), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	if slug == "" {
+		slug = "item"
+	}
+
+	base := item.Published.Format("2006-01-02") + "-" + slug
+	name := base + "." + ext
+	for n := 2; used[name]; n++ {
+		name = fmt.Sprintf("%s-%d.%s", base, n, ext)
+	}
+	used[name] = true
+	return name
+}
+
+// exportFrontMatter returns the YAML front matter of an item. Values are
+// written as JSON strings, which YAML reads as double-quoted strings.
+func exportFrontMatter(item feed.Item) string {
+	quote := func(s string) string {
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", quote(item.Title))
+	fmt.Fprintf(&b, "date: %s\n", item.Published.Format("2006-01-02T15:04:05Z07:00"))
+	if item.Link != "" {
+		fmt.Fprintf(&b, "link: %s\n", quote(item.Link))
+	}
+	if item.FeedName != "" {
+		fmt.Fprintf(&b, "feed: %s\n", quote(item.FeedName))
+	}
+	b.WriteString("---\n")
+	return b.String()
+}
+
+// exportMarkdown renders an item as a Markdown file
+func exportMarkdown(item feed.Item) string {
+	body := item.Markdown
+	if body == "" {
+		body = item.Content
+	}
+	return exportFrontMatter(item) + "\n# " + item.Title + "\n\n" + strings.TrimSpace(body) + "\n"
+}
+
+// exportHTML renders an item as an HTML page, one paragraph per block of
+// its text content
+func exportHTML(item feed.Item) string {
+	var b strings.Builder
+	b.WriteString(exportFrontMatter(item))
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n</head>\n<body>\n<article>\n", html.EscapeString(item.Title))
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(item.Title))
+	fmt.Fprintf(&b, "<p><time datetime=\"%s\">%s</time>",
+		item.Published.Format("2006-01-02T15:04:05Z07:00"), item.Published.Format("2006-01-02"))
+	if item.FeedName != "" {
+		fmt.Fprintf(&b, " &middot; %s", html.EscapeString(item.FeedName))
+	}
+	b.WriteString("</p>\n")
+
+	for _, paragraph := range strings.Split(item.Content, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			text := strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n")
+			fmt.Fprintf(&b, "<p>%s</p>\n", text)
+		}
+	}
+
+	if item.Link != "" {
+		fmt.Fprintf(&b, "<p><a href=\"%s\">%s</a></p>\n", html.EscapeString(item.Link), html.EscapeString(item.Link))
+	}
+	b.WriteString("</article>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportDir, "dir", ".", "directory to write the files to")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "md", "file format: md or html")
+	exportCmd.Flags().BoolVar(&exportUnread, "unread", false, "only export unread items")
+}
@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"informant/internal/config"
+	"informant/internal/daemon"
+	"informant/internal/feed"
+	"informant/internal/filter"
+	"informant/internal/i18n"
+	"informant/internal/logging"
+	"informant/internal/metrics"
+	"informant/internal/storage"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// fetchAllItems fetches every configured feed concurrently and returns their
+// items in configuration order, tagged with the feed name. Feeds that fail
+// are skipped, with a warning in verbose mode. Progress is shown on a
+// terminal unless --quiet is set.
+func fetchAllItems(cfg *config.Config, store *storage.Storage) []feed.Item {
+	items, _ := fetchItems(context.Background(), cfg, store, progressEnabled())
+	return items
+}
+
+// lockFetch keeps concurrent informant runs, such as parallel pacman hooks or
+// a hook and watch, from fetching the feeds and updating their state at the
+// same time. A run that had to wait reuses the feeds the other one cached.
+// When the lock cannot be had in time, the run goes ahead without it.
+func lockFetch(ctx context.Context, store *storage.Storage) func() {
+	unlock, waited, err := store.LockFetch(ctx)
+	if err != nil {
+		logging.Warnf("%v, continuing without waiting", err)
+		return func() {}
+	}
+	if waited {
+		logging.Infof("Waited for another informant run, reusing the feeds it fetched")
+	}
+	return unlock
+}
+
+// fetchItems is fetchAllItems with a context bounding the fetches, reporting
+// progress on stderr when asked to. It also returns the names of the feeds
+// that could not be fetched.
+func fetchItems(ctx context.Context, cfg *config.Config, store *storage.Storage, progress bool) ([]feed.Item, []string) {
+	fetchMu.RLock()
+	defer fetchMu.RUnlock()
+
+	results := make([][]feed.Item, len(cfg.Feeds))
+	fetches := make([]storage.FeedFetch, len(cfg.Feeds))
+	timings := make([]feed.Timing, len(cfg.Feeds))
+
+	// Feeds finish in any order, so each reports on a line of its own
+	var progressMu sync.Mutex
+	report := func(format string, args ...interface{}) {
+		if !progress {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		fmt.Fprintln(os.Stderr, i18n.T(format, args...))
+	}
+
+	var wg sync.WaitGroup
+	for i, feedCfg := range cfg.Feeds {
+		wg.Add(1)
+		go func(i int, feedCfg config.Feed) {
+			defer wg.Done()
+
+			name := feedName(feedCfg)
+
+			items, err := feed.ParseFeedWithContext(feed.WithTiming(ctx, &timings[i]), feedCfg.URL, store)
+			fetches[i] = storage.FeedFetch{URL: feedCfg.URL, Items: len(items), Err: err, Cached: timings[i].Cached}
+			// Reading the cache says nothing about the server
+			if !timings[i].Cached {
+				metrics.ObserveFetch(name, timings[i].Total, err)
+			}
+			if err != nil {
+				report("Fetching %s... failed: %v", name, err)
+				logging.Event(logging.LevelInfo, "Failed to parse feed", logging.Fields{
+					"feed":  feedCfg.Name,
+					"url":   feedCfg.URL,
+					"error": err,
+				})
+				return
+			}
+			logging.Event(logging.LevelDebug, "Feed fetched", logging.Fields{
+				"feed":        feedCfg.Name,
+				"url":         feedCfg.URL,
+				"items":       len(items),
+				"duration_ms": timings[i].Total.Milliseconds(),
+			})
+			if timings[i].Cached {
+				report("Fetching %s... done (%d items, cached)", name, len(items))
+			} else {
+				report("Fetching %s... done (%d items)", name, len(items))
+			}
+
+			for j := range items {
+				items[j].FeedName = feedCfg.Name
+				items[j].Tags = addTags(items[j].Tags, feedCfg.Tags)
+			}
+			results[i] = items
+		}(i, feedCfg)
+	}
+	wg.Wait()
+
+	if viper.GetBool("verbose") {
+		reportTimings(cfg, timings, fetches)
+	}
+
+	var failed []string
+	for i, fetch := range fetches {
+		if fetch.Err != nil {
+			failed = append(failed, feedName(cfg.Feeds[i]))
+		}
+	}
+
+	if err := store.RecordFetches(fetches); err != nil {
+		logging.Warnf("Failed to record feed status: %v", err)
+	}
+
+	var allItems []feed.Item
+	for _, items := range results {
+		allItems = append(allItems, items...)
+	}
+
+	if err := store.ArchiveItems(allItems); err != nil {
+		logging.Warnf("Failed to archive items: %v", err)
+	}
+
+	if err := store.MigrateLegacyKeys(allItems); err != nil {
+		logging.Warnf("Failed to migrate read status: %v", err)
+	}
+
+	fetched := make(map[string][]feed.Item)
+	for i, fetch := range fetches {
+		if fetch.Err == nil {
+			fetched[fetch.URL] = results[i]
+		}
+	}
+	markInitialRead(cfg, store, fetched)
+
+	markIgnored(cfg, store, allItems)
+	markHighlighted(cfg, allItems)
+	markSeverity(cfg, allItems)
+
+	return allItems, failed
+}
+
+// addTags appends the tags not already in tags
+func addTags(tags, extra []string) []string {
+	for _, tag := range extra {
+		found := false
+		for _, existing := range tags {
+			if strings.EqualFold(existing, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// isPending reports whether an item is unread, not snoozed and not queued to
+// read later, i.e. counts for check and unread counts
+func isPending(store *storage.Storage, item feed.Item) bool {
+	key := item.Key()
+	return !store.IsRead(key) && !store.IsSnoozed(key) && !store.IsLater(key)
+}
+
+// feedName returns the name a feed is reported by, its URL if it has none
+func feedName(feedCfg config.Feed) string {
+	if feedCfg.Name != "" {
+		return feedCfg.Name
+	}
+	return feedCfg.URL
+}
+
+// reportTimings logs where the time fetching each feed went, slowest first,
+// to find the feeds that slow down the pacman hook
+func reportTimings(cfg *config.Config, timings []feed.Timing, fetches []storage.FeedFetch) {
+	order := make([]int, len(timings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return timings[order[a]].Total > timings[order[b]].Total
+	})
+
+	for _, i := range order {
+		timing := timings[i]
+		cache := "miss"
+		if timing.Cached {
+			cache = "hit"
+		}
+		fields := logging.Fields{
+			"feed":       feedName(cfg.Feeds[i]),
+			"dns_ms":     timing.DNS.Milliseconds(),
+			"connect_ms": timing.Connect.Milliseconds(),
+			"tls_ms":     timing.TLS.Milliseconds(),
+			"ttfb_ms":    timing.TTFB.Milliseconds(),
+			"total_ms":   timing.Total.Milliseconds(),
+			"cache":      cache,
+			"bytes":      timing.Bytes,
+			"items":      fetches[i].Items,
+		}
+		if fetches[i].Err != nil {
+			fields["error"] = fetches[i].Err
+		}
+		logging.Event(logging.LevelInfo, "Feed timing", fields)
+	}
+}
+
+// markIgnored marks unread items matching an ignore rule as read, so they
+// are never reported as unread
+func markIgnored(cfg *config.Config, store *storage.Storage, items []feed.Item) {
+	if len(cfg.Ignore) == 0 {
+		return
+	}
+
+	ignore, err := filter.New(cfg.Ignore)
+	if err != nil {
+		logging.Warnf("Invalid ignore rules: %v", err)
+		return
+	}
+
+	var ignoredIDs []string
+	for _, item := range items {
+		if !store.IsRead(item.Key()) && ignore.Match(item) {
+			ignoredIDs = append(ignoredIDs, item.Key())
+			logging.Event(logging.LevelInfo, "Ignoring item", logging.Fields{
+				"title": item.Title,
+				"feed":  item.FeedName,
+			})
+		}
+	}
+
+	if len(ignoredIDs) == 0 {
+		return
+	}
+
+	if err := store.MarkAllAsRead(ignoredIDs); err != nil {
+		logging.Warnf("Failed to mark ignored items as read: %v", err)
+	}
+}
+
+// withArchived adds archived items from configured feeds that are no longer
+// present in the upstream feeds to the given items
+func withArchived(cfg *config.Config, store *storage.Storage, items []feed.Item) []feed.Item {
+	urls := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, feedCfg := range cfg.Feeds {
+		urls[feedCfg.URL] = true
+		if feedCfg.Name != "" {
+			names[feedCfg.Name] = true
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, item := range items {
+		current[item.Key()] = true
+	}
+
+	var archived []feed.Item
+	for _, item := range store.ArchivedItems() {
+		// Items archived by older versions only have the feed name
+		configured := urls[item.FeedURL] || item.FeedURL == "" && names[item.FeedName]
+		if !current[item.Key()] && configured {
+			archived = append(archived, item)
+		}
+	}
+
+	// Archived items are re-evaluated in case the highlight rules changed
+	markHighlighted(cfg, archived)
+	markSeverity(cfg, archived)
+
+	return append(items, archived...)
+}
+
+// markInitialRead marks the items of the fetched feeds, by feed URL, as read
+// on the first runs, until every configured feed was fetched once, so that
+// years-old news does not block the first pacman transaction. Feeds outside
+// the profile or that failed are marked by a later run.
+func markInitialRead(cfg *config.Config, store *storage.Storage, fetched map[string][]feed.Item) {
+	// Without initial-mark-read the feeds only have to be fetched once
+	keys := make(map[string][]string)
+	for url, items := range fetched {
+		keys[url] = nil
+		if !cfg.InitialMarkRead {
+			continue
+		}
+		for _, item := range items {
+			keys[url] = append(keys[url], item.Key())
+		}
+	}
+
+	allFeeds := make([]string, len(cfg.AllFeeds))
+	for i, feedCfg := range cfg.AllFeeds {
+		allFeeds[i] = feedCfg.URL
+	}
+
+	count, err := store.MarkInitialRead(keys, allFeeds)
+	if err != nil {
+		logging.Warnf("Failed to mark existing items as read: %v", err)
+		return
+	}
+	if count > 0 {
+		logging.Infof("First run: marked %d existing items as read", count)
+	}
+}
+
+// markHighlighted sets Highlighted on items matching a highlight rule
+func markHighlighted(cfg *config.Config, items []feed.Item) {
+	if len(cfg.Highlight) == 0 {
+		return
+	}
+
+	highlight, err := filter.New(cfg.Highlight)
+	if err != nil {
+		logging.Warnf("Invalid highlight rules: %v", err)
+		return
+	}
+
+	for i := range items {
+		items[i].Highlighted = highlight.Match(items[i])
+	}
+}
+
+// markSeverity sets Severity on items from the severity rules. Highlighting
+// must already be done, as highlighted items are at least warnings.
+func markSeverity(cfg *config.Config, items []feed.Item) {
+	classifier, err := filter.NewClassifier(cfg.Severity)
+	if err != nil {
+		logging.Warnf("Invalid severity rules: %v", err)
+		return
+	}
+
+	for i := range items {
+		items[i].Severity = classifier.Classify(items[i])
+	}
+}
+
+// loadItems returns the items held by a running 'informant watch' daemon when
+// one is available, and otherwise fetches the feeds directly. Items of feeds
+// outside the configured ones, such as those of another profile, are left out,
+// and configured feeds the daemon does not fetch are fetched directly.
+func loadItems(cfg *config.Config, store *storage.Storage) []feed.Item {
+	state, err := daemon.Query(daemon.SocketPath())
+	if err != nil || state.RefreshedAt.IsZero() {
+		return fetchAllItems(cfg, store)
+	}
+	logging.Infof("Using items from running daemon (refreshed %s)", state.RefreshedAt.Format("2006-01-02 15:04:05"))
+
+	covered := make(map[string]bool)
+	for _, url := range state.Feeds {
+		covered[url] = true
+	}
+
+	missing := *cfg
+	missing.Feeds = nil
+	urls := make(map[string]bool)
+	for _, feedCfg := range cfg.Feeds {
+		if covered[feedCfg.URL] {
+			urls[feedCfg.URL] = true
+		} else {
+			missing.Feeds = append(missing.Feeds, feedCfg)
+		}
+	}
+
+	var items []feed.Item
+	for _, item := range state.Items {
+		if urls[item.FeedURL] {
+			items = append(items, item)
+		}
+	}
+
+	if len(missing.Feeds) > 0 {
+		logging.Infof("The daemon does not fetch %d of the configured feeds, fetching them directly", len(missing.Feeds))
+		items = append(items, fetchAllItems(&missing, store)...)
+	}
+	return items
+}
+
+// sortByPublished orders items newest first, or oldest first when reverse is
+// set. The sort is stable so that list, read and the TUI number items with
+// equal dates identically.
+func sortByPublished(items []feed.Item, reverse bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if reverse {
+			return items[i].Published.Before(items[j].Published)
+		}
+		return items[i].Published.After(items[j].Published)
+	})
+}
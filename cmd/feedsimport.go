@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"informant/internal/config"
+	"informant/internal/i18n"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	feedsImportNewsboat string
+	feedsImportDryRun   bool
+)
+
+// feedsCmd groups commands that manage the configured feeds
+var feedsCmd = &cobra.Command{
+	Use:   "feeds",
+	Short: "Manage configured feeds",
+}
+
+// feedsImportCmd represents the feeds import command
+var feedsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import feeds from another reader",
+	Long: `Import feeds from another reader into the user config file.
+
+With --newsboat, the given newsboat urls file is read. Tags are kept as feed
+tags, which are added to every item of the feed, and a "~Name" tag becomes the
+feed name. Query and filter feeds are skipped. Feeds whose URL is already
+configured are not added again.
+
+The feeds are added to the file given by --config or the user config found,
+or ~/.informantrc.json is created with the default Arch Linux News feed plus
+the imported ones. Use --dry-run to print the imported feeds instead.`,
+	Example: `  informant feeds import --newsboat ~/.newsboat/urls`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if feedsImportNewsboat == "" {
+			return fmt.Errorf("nothing to import from, use --newsboat")
+		}
+
+		file, err := os.Open(expandHome(feedsImportNewsboat))
+		if err != nil {
+			return fmt.Errorf("failed to open urls file: %w", err)
+		}
+		defer file.Close()
+
+		feeds, skipped, err := config.ParseNewsboatURLs(file)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", feedsImportNewsboat, err)
+		}
+		for _, url := range skipped {
+			fmt.Fprintln(os.Stderr, i18n.T("Skipped %s: not supported by informant", url))
+		}
+
+		if feedsImportDryRun {
+			data, err := json.MarshalIndent(feeds, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode feeds: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		path, err := userConfigPath()
+		if err != nil {
+			return err
+		}
+		added, err := addFeedsToConfig(path, feeds)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(i18n.T("Imported %d feeds into %s.", added, path))
+		if existing := len(feeds) - added; existing > 0 {
+			fmt.Println(i18n.T("%d feeds were already configured.", existing))
+		}
+		return nil
+	},
+}
+
+// expandHome replaces a leading ~/ in path with the home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+// userConfigPath returns the user config file to write to: the one given by
+// --config, the one found, or ~/.informantrc.json
+func userConfigPath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if path := findUserConfig(); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".informantrc.json"), nil
+}
+
+// addFeedsToConfig appends feeds to the config file at path, skipping URLs it
+// already lists, and returns how many were added. Other settings are kept as
+// they are. A missing file is created with the default feed.
+func addFeedsToConfig(path string, feeds []config.Feed) (int, error) {
+	settings := map[string]json.RawMessage{}
+	// Existing feeds are kept verbatim, only their URLs are looked at
+	var existing []json.RawMessage
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if raw, ok := settings["feeds"]; ok {
+			if err := json.Unmarshal(raw, &existing); err != nil {
+				return 0, fmt.Errorf("failed to parse feeds in %s: %w", path, err)
+			}
+		}
+	case os.IsNotExist(err):
+		defaultFeed, err := json.Marshal(config.DefaultFeed)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode feeds: %w", err)
+		}
+		existing = append(existing, defaultFeed)
+	default:
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	known := make(map[string]bool)
+	for _, raw := range existing {
+		var feedCfg config.Feed
+		if err := json.Unmarshal(raw, &feedCfg); err == nil {
+			known[feedCfg.URL] = true
+		}
+	}
+
+	added := 0
+	for _, feedCfg := range feeds {
+		if known[feedCfg.URL] {
+			continue
+		}
+		raw, err := json.Marshal(feedCfg)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode feeds: %w", err)
+		}
+		known[feedCfg.URL] = true
+		existing = append(existing, raw)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+
+	raw, err := json.Marshal(existing)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode feeds: %w", err)
+	}
+	settings["feeds"] = raw
+
+	data, err = json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return added, nil
+}
+
+func init() {
+	rootCmd.AddCommand(feedsCmd)
+	feedsCmd.AddCommand(feedsImportCmd)
+
+	feedsImportCmd.Flags().StringVar(&feedsImportNewsboat, "newsboat", "", "newsboat urls file to import, e.g. ~/.newsboat/urls")
+	feedsImportCmd.Flags().BoolVar(&feedsImportDryRun, "dry-run", false, "print the imported feeds as JSON instead of adding them to the config")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"informant/internal/config"
+	"informant/internal/feed"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	fetchDump   bool
+	fetchParsed bool
+)
+
+// fetchCmd represents the fetch command
+var fetchCmd = &cobra.Command{
+	Use:   "fetch <url|feed name>",
+	Short: "Fetch a single feed for debugging",
+	Long: `Fetch a feed, given by URL or by the name of a configured feed, bypassing
+the cache, and report its size, which parser was chosen and how many items it
+yielded. Use --dump to print the raw (decompressed) body and --parsed to print
+the parsed items.
+
+The report goes to stderr, so the raw body can be piped or redirected.
+Nothing is marked as read or recorded in the storage.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		name, url := resolveFeed(cfg, args[0])
+
+		body, err := feed.FetchRaw(context.Background(), url)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", url, err)
+		}
+
+		items, format, parseErr := feed.ParseBody(body)
+
+		fmt.Fprintf(os.Stderr, "URL:    %s\n", url)
+		if name != "" {
+			fmt.Fprintf(os.Stderr, "Feed:   %s\n", name)
+		}
+		fmt.Fprintf(os.Stderr, "Size:   %d bytes\n", len(body))
+		fmt.Fprintf(os.Stderr, "Parser: %s\n", format)
+		if parseErr != nil {
+			fmt.Fprintf(os.Stderr, "Error:  %v\n", parseErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Items:  %d\n", len(items))
+		}
+
+		if fetchDump {
+			os.Stdout.Write(body)
+			if len(body) > 0 && body[len(body)-1] != '\n' {
+				fmt.Println()
+			}
+		}
+
+		if fetchParsed {
+			for i, item := range items {
+				fmt.Printf("%d. %s\n", i+1, item.Title)
+				fmt.Printf("   ID:        %s\n", item.ID)
+				fmt.Printf("   Published: %s\n", item.Published.Format("2006-01-02 15:04:05 -0700"))
+				if item.Link != "" {
+					fmt.Printf("   Link:      %s\n", item.Link)
+				}
+				if item.Author != "" {
+					fmt.Printf("   Author:    %s\n", item.Author)
+				}
+				if len(item.Tags) > 0 {
+					fmt.Printf("   Tags:      %s\n", strings.Join(item.Tags, ", "))
+				}
+			}
+		}
+
+		return parseErr
+	},
+}
+
+// resolveFeed returns the name and URL of the configured feed named ref, or
+// ref itself as the URL when no feed has that name
+func resolveFeed(cfg *config.Config, ref string) (string, string) {
+	for _, feedCfg := range cfg.Feeds {
+		if strings.EqualFold(feedCfg.Name, ref) {
+			return feedCfg.Name, feedCfg.URL
+		}
+	}
+	for _, feedCfg := range cfg.Feeds {
+		if feedCfg.URL == ref {
+			return feedCfg.Name, feedCfg.URL
+		}
+	}
+	return "", ref
+}
+
+func init() {
+	rootCmd.AddCommand(fetchCmd)
+
+	fetchCmd.Flags().BoolVar(&fetchDump, "dump", false, "print the raw feed body")
+	fetchCmd.Flags().BoolVar(&fetchParsed, "parsed", false, "print the parsed items")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"informant/internal/feed"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of monitoring plugins
+const (
+	healthOK       = 0
+	healthWarning  = 1
+	healthCritical = 2
+	healthUnknown  = 3
+)
+
+// healthNames are the states reported in the summary line, by exit code
+var healthNames = []string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+var (
+	healthMaxAge         time.Duration
+	healthUnreadWarning  int
+	healthUnreadCritical int
+	healthFetch          bool
+)
+
+// healthcheckCmd represents the healthcheck command
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Short: "Report feed health and unread news for monitoring probes",
+	Long: `Print a one-line summary of feed health and unread news, and exit like a
+Nagios plugin: 0 for OK, 1 for WARNING, 2 for CRITICAL and 3 for UNKNOWN.
+
+The state is CRITICAL when a feed has not been fetched successfully within
+--max-age, or when at least --unread-critical items are unread. It is WARNING
+when the last fetch of a feed failed, or when at least --unread-warning items
+are unread. A threshold of 0 turns it off.
+
+Feeds are not fetched unless --fetch is given; the status is recorded by every
+command that fetches them, such as 'check' and 'watch'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		code, summary := healthcheck()
+		fmt.Printf("INFORMANT %s - %s\n", healthNames[code], summary)
+		exit(code)
+	},
+}
+
+// healthcheck works out the health state and its summary line, including
+// performance data
+func healthcheck() (int, string) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return healthUnknown, fmt.Sprintf("failed to load config: %v", err)
+	}
+
+	store, err := openStorage()
+	if err != nil {
+		return healthUnknown, fmt.Sprintf("failed to initialize storage: %v", err)
+	}
+
+	var items []feed.Item
+	if healthFetch {
+		items, _ = fetchItems(context.Background(), cfg, store, false)
+	}
+	items = withArchived(cfg, store, items)
+
+	unread := 0
+	for _, item := range items {
+		if isPending(store, item) {
+			unread++
+		}
+	}
+
+	var stale, failing []string
+	for _, feedCfg := range cfg.Feeds {
+		status, ok := store.GetFeedStatus(feedCfg.URL)
+		switch {
+		case !ok || time.Since(status.LastSuccess) > healthMaxAge:
+			stale = append(stale, feedName(feedCfg))
+		case status.LastError != "":
+			failing = append(failing, feedName(feedCfg))
+		}
+	}
+
+	code := healthOK
+	var problems []string
+	if len(stale) > 0 {
+		code = healthCritical
+		problems = append(problems, fmt.Sprintf("not fetched within %s: %s", healthMaxAge, strings.Join(stale, ", ")))
+	}
+	if len(failing) > 0 {
+		code = maxInt(code, healthWarning)
+		problems = append(problems, fmt.Sprintf("last fetch failed: %s", strings.Join(failing, ", ")))
+	}
+	switch {
+	case healthUnreadCritical > 0 && unread >= healthUnreadCritical:
+		code = healthCritical
+	case healthUnreadWarning > 0 && unread >= healthUnreadWarning:
+		code = maxInt(code, healthWarning)
+	}
+
+	summary := fmt.Sprintf("%d feeds, %d unread", len(cfg.Feeds), unread)
+	if len(problems) > 0 {
+		summary += "; " + strings.Join(problems, "; ")
+	}
+	summary += fmt.Sprintf(" | unread=%d;%d;%d stale=%d failing=%d",
+		unread, healthUnreadWarning, healthUnreadCritical, len(stale), len(failing))
+
+	return code, summary
+}
+
+// maxInt returns the larger of a and b
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func init() {
+	rootCmd.AddCommand(healthcheckCmd)
+
+	healthcheckCmd.Flags().DurationVar(&healthMaxAge, "max-age", 24*time.Hour, "report feeds not fetched successfully for this long as critical")
+	healthcheckCmd.Flags().IntVar(&healthUnreadWarning, "unread-warning", 1, "warn at this many unread items, 0 to never warn")
+	healthcheckCmd.Flags().IntVar(&healthUnreadCritical, "unread-critical", 0, "report this many unread items as critical, 0 to never do so")
+	healthcheckCmd.Flags().BoolVar(&healthFetch, "fetch", false, "fetch the feeds first instead of using the recorded status")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"informant/internal/platform"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+//go:embed assets/informant.hook.tmpl
+var pacmanHookTemplate string
+
+//go:embed assets/informant.apt.tmpl
+var aptHookTemplate string
+
+//go:embed assets/informant.dnf.tmpl
+var dnfHookTemplate string
+
+// hookTarget is a package manager the news check can be hooked into
+type hookTarget struct {
+	// Name is the package manager, as given to --package-manager
+	Name string
+	// Path is the file the hook is installed to
+	Path string
+	// Template renders the hook file from a hookConfig
+	Template string
+	// Transactional is set when the hook supports --operations, --when and
+	// --package-aware, which only pacman hooks do
+	Transactional bool
+}
+
+// hookTargets are the package managers a hook can be installed for
+var hookTargets = []hookTarget{
+	{Name: platform.Pacman, Path: "/usr/share/libalpm/hooks/00-informant.hook", Template: pacmanHookTemplate, Transactional: true},
+	{Name: platform.Apt, Path: "/etc/apt/apt.conf.d/05informant", Template: aptHookTemplate},
+	{Name: platform.Dnf, Path: "/etc/dnf/libdnf5-plugins/actions.d/informant.actions", Template: dnfHookTemplate},
+}
+
+// errHookUnsupported is returned by install and uninstall on hosts without a
+// supported package manager, such as macOS
+var errHookUnsupported = fmt.Errorf("no supported package manager (%s) found on this system (%s); use 'informant check' or 'informant watch' instead",
+	strings.Join(platform.PackageManagers, ", "), runtime.GOOS)
+
+// findHookTarget returns the hook target of the named package manager, or of
+// the one the host is managed by when name is empty
+func findHookTarget(name string) (hookTarget, error) {
+	if name == "" {
+		name = platform.PackageManager()
+		if name == "" {
+			return hookTarget{}, errHookUnsupported
+		}
+	}
+
+	for _, target := range hookTargets {
+		if strings.EqualFold(target.Name, name) {
+			return target, nil
+		}
+	}
+	return hookTarget{}, fmt.Errorf("unsupported package manager %q, must be one of %s", name, strings.Join(platform.PackageManagers, ", "))
+}
+
+// render renders the hook file from the target's template
+func (t hookTarget) render(hook hookConfig) ([]byte, error) {
+	tmpl, err := template.New(t.Name).Parse(t.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s hook template: %w", t.Name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, hook); err != nil {
+		return nil, fmt.Errorf("failed to render %s hook: %w", t.Name, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -1,39 +1,133 @@
 package cmd
 
 import (
-	_ "embed"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/user"
 	"path/filepath"
 	"strings"
+	"unicode"
+
+	"informant/internal/config"
+	"informant/internal/storage"
 
 	"github.com/spf13/cobra"
 )
 
-//go:embed assets/informant.hook
-var hookContent []byte
-
 var (
-	installForce bool
+	installForce          bool
+	installPackageAware   bool
+	installInteractive    bool
+	installProfile        string
+	installOperations     []string
+	installWhen           string
+	installPackageManager string
 )
 
+// hookOperations are the transaction operations a pacman hook can trigger on
+var hookOperations = []string{"Install", "Upgrade", "Remove"}
+
+// hookWhen are the points in a transaction a pacman hook can run at
+var hookWhen = []string{"PreTransaction", "PostTransaction"}
+
+// hookConfig fills in the hook template
+type hookConfig struct {
+	Operations   []string
+	When         string
+	Exec         string
+	NeedsTargets bool
+}
+
 // installCmd represents the install command
 var installCmd = &cobra.Command{
 	Use:   "install",
-	Short: "Install pacman hook for system integration",
-	Long: `Install the pacman hook to automatically check for Arch Linux news
-during package installations and upgrades.
+	Short: "Install package manager hook for system integration",
+	Long: `Install the package manager hook to automatically check for news during
+package installations and upgrades.
+
+On Arch Linux, the pacman hook will be installed to
+/usr/share/libalpm/hooks/00-informant.hook and will interrupt pacman
+transactions when there are unread news items.
+
+On Debian and Ubuntu, an apt DPkg::Pre-Invoke snippet is installed to
+/etc/apt/apt.conf.d/05informant instead, and on Fedora a dnf5 actions file to
+/etc/dnf/libdnf5-plugins/actions.d/informant.actions, which needs the actions
+plugin (libdnf5-plugin-actions). Both make the package manager abort while there
+are unread news items. The package manager is detected, or chosen with
+--package-manager.
+
+By default the pacman hook runs before installs and upgrades. --operations
+chooses the operations that trigger it (Install, Upgrade, Remove) and --when
+whether it runs before (PreTransaction) or after (PostTransaction) the
+transaction. Only a PreTransaction hook can abort the transaction. These
+options and --package-aware are only supported for pacman; apt and dnf hooks
+run before every transaction.
+
+With --interactive, the hook runs 'informant check --review' to show unread
+news on the terminal pacman runs in and lets the transaction continue once
+every item has been acknowledged.
+
+With --hook-profile, the hook only checks the feeds of that profile, which
+must be defined in the config.
+
+It also sets up the system-wide storage in /var/lib and /var/cache/informant
+to be shared through the storage group ("informant" unless storage-group is
+configured), creating the group if needed. Add users to it to let them mark
+items as read for everyone.
+
+This command requires root privileges to install the system-wide hook, and
+is only supported on systems managed by pacman, apt or dnf.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := findHookTarget(installPackageManager)
+		if err != nil {
+			return err
+		}
 
-The hook will be installed to /usr/share/libalpm/hooks/00-informant.hook
-and will interrupt pacman transactions when there are unread news items.
+		if !target.Transactional {
+			for _, flag := range []string{"operations", "when", "package-aware"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s is only supported for pacman hooks", flag)
+				}
+			}
+		}
 
-This command requires root privileges to install the system-wide hook.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check if running with appropriate privileges
 		if os.Geteuid() != 0 {
 			return fmt.Errorf("this command requires root privileges. Please run with sudo")
 		}
 
+		hook := hookConfig{NeedsTargets: installPackageAware}
+		for _, operation := range installOperations {
+			name, err := matchHookValue(operation, hookOperations)
+			if err != nil {
+				return fmt.Errorf("invalid --operations: %w", err)
+			}
+			hook.Operations = append(hook.Operations, name)
+		}
+		if len(hook.Operations) == 0 {
+			return fmt.Errorf("invalid --operations: at least one operation is required")
+		}
+		when, err := matchHookValue(installWhen, hookWhen)
+		if err != nil {
+			return fmt.Errorf("invalid --when: %w", err)
+		}
+		hook.When = when
+
+		// A hook that fails on every transaction would block all upgrades
+		if installProfile != "" {
+			if strings.IndexFunc(installProfile, unicode.IsSpace) >= 0 {
+				return fmt.Errorf("invalid --hook-profile: %q contains whitespace", installProfile)
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if _, err := cfg.ProfileFeeds(installProfile); err != nil {
+				return fmt.Errorf("invalid --hook-profile: %w", err)
+			}
+		}
+
 		// Get the current binary path
 		execPath, err := os.Executable()
 		if err != nil {
@@ -46,8 +140,8 @@ This command requires root privileges to install the system-wide hook.`,
 			return fmt.Errorf("failed to resolve executable path: %w", err)
 		}
 
-		hookDir := "/usr/share/libalpm/hooks"
-		hookPath := filepath.Join(hookDir, "00-informant.hook")
+		hookPath := target.Path
+		hookDir := filepath.Dir(hookPath)
 
 		// Create hooks directory if it doesn't exist
 		if err := os.MkdirAll(hookDir, 0755); err != nil {
@@ -59,20 +153,39 @@ This command requires root privileges to install the system-wide hook.`,
 			return fmt.Errorf("hook already exists at %s. Use --force to overwrite", hookPath)
 		}
 
-		// Replace the hardcoded path with the actual binary path
-		hookContentStr := string(hookContent)
-		hookContentStr = strings.Replace(hookContentStr, "/usr/bin/informant check", actualPath+" check", 1)
+		// Have pacman pass the transaction's packages so only relevant news interrupts it
+		hook.Exec = actualPath + " check"
+		if installPackageAware {
+			hook.Exec += " --targets -"
+		}
+		if installInteractive {
+			hook.Exec += " --review"
+		}
+		if installProfile != "" {
+			hook.Exec += " --profile " + installProfile
+		}
+
+		hookContent, err := target.render(hook)
+		if err != nil {
+			return err
+		}
 
 		// Write the hook file
-		if err := os.WriteFile(hookPath, []byte(hookContentStr), 0644); err != nil {
+		if err := os.WriteFile(hookPath, hookContent, 0644); err != nil {
 			return fmt.Errorf("failed to write hook file: %w", err)
 		}
 
-		fmt.Printf("Successfully installed pacman hook to %s\n", hookPath)
+		fmt.Printf("Successfully installed %s hook to %s\n", target.Name, hookPath)
 		fmt.Printf("Hook configured to use binary at: %s\n", actualPath)
+
+		group := config.GetStorageGroup()
+		if err := setupStorageGroup(group); err != nil {
+			return err
+		}
+		fmt.Printf("System-wide storage is shared with the %s group. Add users with: usermod -aG %s <user>\n", group, group)
 		fmt.Println("\nThe hook will now:")
-		fmt.Println("• Check for unread Arch Linux news before package installations/upgrades")
-		fmt.Println("• Interrupt pacman transactions if unread news items are found")
+		fmt.Println("• Check for unread news before package installations/upgrades")
+		fmt.Printf("• Interrupt %s transactions if unread news items are found\n", target.Name)
 		fmt.Println("• Ensure you stay informed about important system updates")
 		fmt.Println("\nTo read news items, use: informant read")
 		fmt.Println("To list news items, use: informant list")
@@ -82,8 +195,47 @@ This command requires root privileges to install the system-wide hook.`,
 	},
 }
 
+// matchHookValue returns the allowed value matching value regardless of case
+func matchHookValue(value string, allowed []string) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, name := range allowed {
+		if strings.EqualFold(value, name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%q must be one of %s", value, strings.Join(allowed, ", "))
+}
+
+// setupStorageGroup creates the storage group if it does not exist and
+// hands the system-wide storage to it
+func setupStorageGroup(group string) error {
+	if _, err := user.LookupGroup(group); err != nil {
+		output, err := exec.Command("groupadd", "--system", group).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to create group %s: %w: %s", group, err, strings.TrimSpace(string(output)))
+		}
+		fmt.Printf("Created group %s\n", group)
+	}
+
+	store, err := storage.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	if err := store.ShareSystemStorage(); err != nil {
+		return fmt.Errorf("failed to share system-wide storage: %w", err)
+	}
+
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(installCmd)
 
 	installCmd.Flags().BoolVar(&installForce, "force", false, "overwrite existing hook file")
+	installCmd.Flags().BoolVar(&installPackageAware, "package-aware", false, "only interrupt for news mentioning packages in the transaction")
+	installCmd.Flags().StringVar(&installProfile, "hook-profile", "", "pin the hook to the feeds of this config profile")
+	installCmd.Flags().BoolVar(&installInteractive, "interactive", false, "let the hook show unread news on the terminal and continue once it is read")
+	installCmd.Flags().StringSliceVar(&installOperations, "operations", []string{"Install", "Upgrade"}, "transaction operations that trigger the hook: Install, Upgrade, Remove")
+	installCmd.Flags().StringVar(&installWhen, "when", "PreTransaction", "run the hook PreTransaction or PostTransaction")
+	installCmd.Flags().StringVar(&installPackageManager, "package-manager", "", "install the hook for pacman, apt or dnf instead of the detected package manager")
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"informant/internal/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+var laterRemove bool
+
+// laterCmd represents the later command
+var laterCmd = &cobra.Command{
+	Use:   "later <item>",
+	Short: "Queue a news item to read later",
+	Long: `Add a news item to the read-later queue, listed by 'informant list --later'.
+Queued items stay unread but no longer make 'informant check' or the pacman
+hook fail, and are left out of unread counts. The item is specified like for
+'informant star'. Use --remove to take it out of the queue again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		// Number items the same way as 'list'
+		allItems := withArchived(cfg, store, loadItems(cfg, store))
+		sortByPublished(allItems, false)
+
+		item, err := findItem(args[0], allItems)
+		if err != nil {
+			return err
+		}
+
+		if err := store.SetLater(item.Key(), !laterRemove); err != nil {
+			return fmt.Errorf("failed to update read-later queue: %w", err)
+		}
+
+		if laterRemove {
+			fmt.Println(i18n.T("Removed from read later: %s", item.Title))
+		} else {
+			fmt.Println(i18n.T("Queued to read later: %s", item.Title))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(laterCmd)
+
+	laterCmd.Flags().BoolVar(&laterRemove, "remove", false, "take the item out of the read-later queue")
+}
@@ -4,17 +4,24 @@ import (
 	"fmt"
 	"informant/internal/config"
 	"informant/internal/feed"
+	"informant/internal/i18n"
 	"informant/internal/storage"
+	"informant/internal/tui"
 	"os"
-	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var (
-	listUnread  bool
-	listReverse bool
+	listUnread   bool
+	listStarred  bool
+	listLater    bool
+	listReverse  bool
+	listRelative bool
+	listFormat   string
+	listGroup    bool
+	listCount    bool
 )
 
 // listCmd represents the list command
@@ -22,91 +29,234 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List news items",
 	Long: `List the titles of the most recent news items. By default shows all items
-regardless of read status, unless the --unread flag is used.
+regardless of read status, unless the --unread, --starred or --later flag is
+used.
 
-Items are shown with an index number that can be used with the 'read' command.`,
+Items are shown with an index number that can be used with the 'read' command.
+Dates are printed as 2006-01-02 unless --format gives another Go time layout,
+or --relative shows their age instead.
+
+With --group-by-feed, items are listed under a heading per feed, in config
+order, with the number of unread items of the feed. Index numbers stay the
+same as in the flat list.
+
+With --count, only the number of items that would be listed and how many of
+them are unread are printed, per feed as well with --group-by-feed.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		if listRelative && cmd.Flags().Changed("format") {
+			return fmt.Errorf("--relative and --format cannot be combined")
+		}
+
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		store, err := storage.NewWithConfirmation(!viper.GetBool("no-confirm"))
+		store, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		var allItems []feed.Item
-
-		for _, feedCfg := range cfg.Feeds {
-			items, err := feed.ParseFeedWithStorage(feedCfg.URL, store)
-			if err != nil {
-				if viper.GetBool("verbose") {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to parse feed %s: %v\n", feedCfg.Name, err)
-				}
-				continue
-			}
-
-			// Add feed name to items
-			for i := range items {
-				items[i].FeedName = feedCfg.Name
-			}
-
-			allItems = append(allItems, items...)
-		}
+		// Collect all items
+		allItems := withArchived(cfg, store, loadItems(cfg, store))
 
 		// Sort by published date (newest first by default)
-		sort.Slice(allItems, func(i, j int) bool {
-			if listReverse {
-				return allItems[i].Published.Before(allItems[j].Published)
-			}
-			return allItems[i].Published.After(allItems[j].Published)
-		})
+		sortByPublished(allItems, listReverse)
 
 		// Filter by read status if requested
 		var itemsToShow []feed.Item
 		for _, item := range allItems {
-			if listUnread && store.IsRead(item.ID) {
+			if listUnread && store.IsRead(item.Key()) {
+				continue
+			}
+			if listStarred && !store.IsStarred(item.Key()) {
+				continue
+			}
+			if listLater && !store.IsLater(item.Key()) {
 				continue
 			}
 			itemsToShow = append(itemsToShow, item)
 		}
 
+		if listCount {
+			printListCounts(cfg, store, itemsToShow)
+			return nil
+		}
+
 		if len(itemsToShow) == 0 {
-			if listUnread {
-				fmt.Println("No unread news items.")
+			if listStarred {
+				fmt.Println(i18n.T("No starred news items."))
+			} else if listLater {
+				fmt.Println(i18n.T("No news items queued to read later."))
+			} else if listUnread {
+				fmt.Println(i18n.T("No unread news items."))
 			} else {
-				fmt.Println("No news items found.")
+				fmt.Println(i18n.T("No news items found."))
 			}
 			return nil
 		}
 
 		// Display items with index
-		for i, item := range itemsToShow {
-			index := i + 1
-			status := ""
-			if store.IsRead(item.ID) {
-				status = " [READ]"
-			} else {
-				status = " [UNREAD]"
-			}
-
-			dateStr := item.Published.Format("2006-01-02")
-			feedInfo := ""
-			if item.FeedName != "" {
-				feedInfo = fmt.Sprintf(" (%s)", item.FeedName)
+		now := time.Now()
+		if listGroup {
+			printGroupedByFeed(cfg, store, allItems, itemsToShow, now)
+		} else {
+			for i, item := range itemsToShow {
+				fmt.Println(listLine(store, i+1, item, true, now))
 			}
+		}
 
-			fmt.Printf("%d. %s %s%s%s\n", index, dateStr, item.Title, feedInfo, status)
+		if notice := versionNotice(cfg, store); notice != "" {
+			fmt.Fprintln(os.Stderr, "\n"+notice)
 		}
 
 		return nil
 	},
 }
 
+// listLine formats an item as listed, with its index and status tags. The
+// feed name is left out when withFeed is false.
+func listLine(store *storage.Storage, index int, item feed.Item, withFeed bool, now time.Time) string {
+	isRead := store.IsRead(item.Key())
+	status := " " + i18n.T("[UNREAD]")
+	if isRead {
+		status = " " + i18n.T("[READ]")
+	}
+	if store.IsStarred(item.Key()) {
+		status += " " + i18n.T("[STARRED]")
+	}
+	if store.IsLater(item.Key()) {
+		status += " " + i18n.T("[LATER]")
+	}
+	if store.IsSnoozed(item.Key()) {
+		status += " " + i18n.T("[SNOOZED]")
+	}
+
+	dateStr := tui.FormatTime(item.Published, listFormat)
+	if listRelative {
+		dateStr = tui.RelativeTime(item.Published, now)
+	}
+	dateStr = paint(tui.CLIDateStyle, dateStr)
+	feedInfo := ""
+	if withFeed && item.FeedName != "" {
+		feedInfo = " " + paint(tui.CLIFeedNameStyle, fmt.Sprintf("(%s)", item.FeedName))
+	}
+
+	title := item.Title
+
+	switch {
+	case item.Highlighted:
+		title = paint(tui.CLIHighlightStyle, title)
+		status += " " + i18n.T("[IMPORTANT]")
+	case !isRead:
+		title = paint(tui.CLIUnreadStyle, title)
+	}
+
+	return fmt.Sprintf("%d. %s %s%s%s", index, dateStr, title, feedInfo, status)
+}
+
+// printGroupedByFeed lists the items to show under a heading per feed, in
+// config order, keeping their index in the flat list. Unread counts cover
+// all items of a feed, not only those shown.
+func printGroupedByFeed(cfg *config.Config, store *storage.Storage, allItems, itemsToShow []feed.Item, now time.Time) {
+	unread := make(map[string]int)
+	for _, item := range allItems {
+		if isPending(store, item) {
+			unread[item.FeedURL]++
+		}
+	}
+
+	for i, group := range groupByFeed(cfg, itemsToShow) {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		heading := paint(tui.CLIFeedNameStyle, group.name)
+		fmt.Printf("%s %s\n", heading, i18n.T("(%d unread)", unread[group.url]))
+		for _, index := range group.indexes {
+			fmt.Println("  " + listLine(store, index+1, itemsToShow[index], false, now))
+		}
+	}
+}
+
+// printListCounts prints how many of items there are and how many are
+// unread, per feed first with --group-by-feed
+func printListCounts(cfg *config.Config, store *storage.Storage, items []feed.Item) {
+	count := func(indexes []int) (int, int) {
+		unread := 0
+		for _, index := range indexes {
+			if isPending(store, items[index]) {
+				unread++
+			}
+		}
+		return len(indexes), unread
+	}
+
+	if listGroup {
+		for _, group := range groupByFeed(cfg, items) {
+			total, unread := count(group.indexes)
+			fmt.Printf("%s: %s\n", group.name, i18n.T("%d items, %d unread", total, unread))
+		}
+	}
+
+	all := make([]int, len(items))
+	for i := range all {
+		all[i] = i
+	}
+	total, unread := count(all)
+	fmt.Println(i18n.T("%d items, %d unread", total, unread))
+}
+
+// feedGroup is a feed and the positions of its items in a list
+type feedGroup struct {
+	url, name string
+	indexes   []int
+}
+
+// groupByFeed groups items by feed. Configured feeds come first, in config
+// order, then feeds only known from their items. Feeds without items are
+// left out.
+func groupByFeed(cfg *config.Config, items []feed.Item) []feedGroup {
+	var groups []*feedGroup
+	byURL := make(map[string]*feedGroup)
+	for _, feedCfg := range cfg.Feeds {
+		if byURL[feedCfg.URL] == nil {
+			group := &feedGroup{url: feedCfg.URL, name: feedName(feedCfg)}
+			groups = append(groups, group)
+			byURL[feedCfg.URL] = group
+		}
+	}
+	for i, item := range items {
+		group := byURL[item.FeedURL]
+		if group == nil {
+			group = &feedGroup{url: item.FeedURL, name: item.FeedName}
+			if group.name == "" {
+				group.name = item.FeedURL
+			}
+			groups = append(groups, group)
+			byURL[item.FeedURL] = group
+		}
+		group.indexes = append(group.indexes, i)
+	}
+
+	var result []feedGroup
+	for _, group := range groups {
+		if len(group.indexes) > 0 {
+			result = append(result, *group)
+		}
+	}
+	return result
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 
 	listCmd.Flags().BoolVar(&listUnread, "unread", false, "only show unread items")
+	listCmd.Flags().BoolVar(&listStarred, "starred", false, "only show starred items")
+	listCmd.Flags().BoolVar(&listLater, "later", false, "only show items queued to read later")
 	listCmd.Flags().BoolVar(&listReverse, "reverse", false, "show items oldest to newest")
+	listCmd.Flags().BoolVar(&listRelative, "relative", false, "show how long ago items were published, like \"3 days ago\"")
+	listCmd.Flags().StringVar(&listFormat, "format", "2006-01-02", "Go time layout for dates, e.g. \"2006-01-02 15:04\"")
+	listCmd.Flags().BoolVar(&listGroup, "group-by-feed", false, "list items under a heading per feed")
+	listCmd.Flags().BoolVar(&listCount, "count", false, "only print how many items there are and how many are unread")
 }
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"informant/internal/config"
+	"informant/internal/i18n"
+	"informant/internal/tui"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	markBefore string
+	markFeeds  []string
+)
+
+// markCmd groups bulk read status operations
+var markCmd = &cobra.Command{
+	Use:   "mark",
+	Short: "Change the read status of many items at once",
+}
+
+// markReadCmd represents the mark read command
+var markReadCmd = &cobra.Command{
+	Use:   "read",
+	Short: "Mark items published before a date as read",
+	Long: `Mark every unread item published before --before as read, to catch up
+after a while away without also marking today's news as read like
+'informant read --all' does.
+
+--before is a date such as "2024-03-01" (midnight in the display timezone), an
+RFC 3339 time, or an age such as "72h", "14d" or "3w" counted back from now.
+--feed limits it to the given feeds, by name or URL, and can be repeated.`,
+	Example: `  informant mark read --before 3w
+  informant mark read --before 2024-03-01 --feed "Hacker News"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if markBefore == "" {
+			return fmt.Errorf("--before is required")
+		}
+		cutoff, err := parseCutoff(markBefore, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --before: %w", err)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		feeds := make(map[string]bool)
+		for _, ref := range markFeeds {
+			found := false
+			for _, feedCfg := range cfg.Feeds {
+				if feedCfg.Name == ref || feedCfg.URL == ref {
+					feeds[feedCfg.URL] = true
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("feed not found: %s", ref)
+			}
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		var keys []string
+		for _, item := range withArchived(cfg, store, loadItems(cfg, store)) {
+			if !item.Published.Before(cutoff) || store.IsRead(item.Key()) {
+				continue
+			}
+			if len(feeds) > 0 && !feeds[item.FeedURL] {
+				continue
+			}
+			keys = append(keys, item.Key())
+		}
+
+		if len(keys) > 0 {
+			if err := store.MarkAllAsRead(keys); err != nil {
+				return fmt.Errorf("failed to mark items as read: %w", err)
+			}
+		}
+		fmt.Println(i18n.T("Marked %d items published before %s as read.", len(keys), tui.FormatTime(cutoff, "2006-01-02 15:04")))
+		return nil
+	},
+}
+
+// parseCutoff parses a date, an RFC 3339 time or an age (see parseAge) into
+// the point in time it refers to. Dates are taken in the display timezone,
+// or the local one if none is set.
+func parseCutoff(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	loc, err := config.GetTimezone()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, nil
+	}
+
+	age, err := parseAge(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date like 2006-01-02 or an age like 3w: %q", s)
+	}
+	return now.Add(-age), nil
+}
+
+func init() {
+	rootCmd.AddCommand(markCmd)
+	markCmd.AddCommand(markReadCmd)
+
+	markReadCmd.Flags().StringVar(&markBefore, "before", "", "mark items published before this date or age as read, e.g. 2024-03-01 or 3w")
+	markReadCmd.Flags().StringSliceVar(&markFeeds, "feed", nil, "only mark items of this feed, by name or URL (repeatable)")
+}
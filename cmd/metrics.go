@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"informant/internal/config"
+	"informant/internal/feed"
+	"informant/internal/logging"
+	"informant/internal/metrics"
+	"informant/internal/storage"
+	"net/http"
+)
+
+// feedMetrics returns the state of each configured feed for the metrics,
+// counting the pending items among items
+func feedMetrics(cfg *config.Config, store *storage.Storage, items []feed.Item) []metrics.Feed {
+	unread := make(map[string]map[string]int)
+	for _, item := range items {
+		if !isPending(store, item) {
+			continue
+		}
+		if unread[item.FeedURL] == nil {
+			unread[item.FeedURL] = make(map[string]int)
+		}
+		unread[item.FeedURL][item.Severity]++
+	}
+
+	feeds := make([]metrics.Feed, 0, len(cfg.Feeds))
+	for _, feedCfg := range cfg.Feeds {
+		f := metrics.Feed{Name: feedName(feedCfg), Unread: unread[feedCfg.URL]}
+		if status, ok := store.GetFeedStatus(feedCfg.URL); ok {
+			f.LastSuccess = status.LastSuccess
+		}
+		feeds = append(feeds, f)
+	}
+	return feeds
+}
+
+// metricsHandler serves GET /metrics for Prometheus with the items returned
+// by load for every scrape
+func metricsHandler(cfg *config.Config, store *storage.Storage, load func() []feed.Item) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", metrics.ContentType)
+		if err := metrics.Write(w, feedMetrics(cfg, store, load())); err != nil {
+			logging.Warnf("Failed to write metrics: %v", err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"informant/internal/i18n"
+	"informant/internal/tui"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// newsForCmd represents the news-for command
+var newsForCmd = &cobra.Command{
+	Use:   "news-for <package>...",
+	Short: "Show news items mentioning a package",
+	Long: `Show the news items, including archived ones, whose title or content
+mentions one of the given packages as a whole word, such as
+'informant news-for grub' before upgrading grub. Matching is the same as for
+'informant check --targets'.
+
+Items are numbered like in 'informant list', so they can be read with
+'informant read N'. Nothing is marked as read.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		// Number items the same way as 'list'
+		allItems := withArchived(cfg, store, loadItems(cfg, store))
+		sortByPublished(allItems, false)
+
+		matchers := packageMatchers(args)
+		found := false
+		for i, item := range allItems {
+			if !mentionsPackage(item, matchers) {
+				continue
+			}
+			found = true
+
+			status := i18n.T("[READ]")
+			if !store.IsRead(item.Key()) {
+				status = i18n.T("[UNREAD]")
+			}
+			feedInfo := ""
+			if item.FeedName != "" {
+				feedInfo = " " + paint(tui.CLIFeedNameStyle, fmt.Sprintf("(%s)", item.FeedName))
+			}
+
+			fmt.Printf("%d. %s %s%s %s\n", i+1,
+				paint(tui.CLIDateStyle, tui.FormatTime(item.Published, "2006-01-02")),
+				paint(tui.CLIUnreadStyle, item.Title), feedInfo, status)
+			if summary := summarize(item.Content, 200); summary != "" {
+				fmt.Printf("   %s\n", summary)
+			}
+		}
+
+		if !found {
+			fmt.Println(i18n.T("No news items mention %s.", strings.Join(args, ", ")))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(newsForCmd)
+}
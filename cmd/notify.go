@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"informant/internal/config"
+	"informant/internal/feed"
+	"informant/internal/logging"
+	"informant/internal/notify"
+	"informant/internal/storage"
+)
+
+// notifyNewItems pushes a summary of unread items that have not been notified
+// before to every configured notification endpoint. Items are only recorded
+// as notified when at least one endpoint accepted the notification, so a
+// failed delivery is retried on the next run.
+func notifyNewItems(cfg *config.Config, store *storage.Storage, unreadItems []feed.Item) {
+	if len(cfg.Notifications) == 0 {
+		return
+	}
+
+	var newItems []feed.Item
+	var newIDs []string
+	for _, item := range unreadItems {
+		if !store.IsNotified(item.Key()) {
+			newItems = append(newItems, item)
+			newIDs = append(newIDs, item.Key())
+		}
+	}
+
+	if len(newItems) == 0 {
+		return
+	}
+
+	summary := notify.Summarize(newItems)
+
+	delivered := false
+	for _, endpoint := range cfg.Notifications {
+		notifier, err := notify.New(endpoint)
+		if err != nil {
+			logging.Warnf("%v", err)
+			continue
+		}
+
+		if err := notifier.Notify(summary); err != nil {
+			logging.Warnf("Failed to send %s notification: %v", endpoint.Type, err)
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered {
+		if err := store.MarkAsNotified(newIDs...); err != nil {
+			logging.Warnf("Failed to record sent notifications: %v", err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneOlderThan string
+	pruneDryRun    bool
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old read status, archive and cache entries",
+	Long: `Remove read, unread and notification marks made longer ago than
+--older-than, archived items published before then and expired feed cache
+entries. Starred and read-later items are kept in the archive.
+
+Items that are still in their feed become unread again if their read mark is
+pruned, so choose an age beyond how long your feeds keep items.
+
+Ages are Go durations such as "720h", or a number of days, weeks or years
+such as "90d", "12w" or "1y".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		maxAge, err := parseAge(pruneOlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+
+		if _, err := loadConfig(); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		result, err := store.Prune(maxAge, pruneDryRun)
+		if err != nil {
+			return fmt.Errorf("failed to prune storage: %w", err)
+		}
+
+		verb := "Removed"
+		if pruneDryRun {
+			verb = "Would remove"
+		}
+		fmt.Printf("%s %d read marks, %d unread marks, %d notification marks, %d archived items and %d cache entries.\n",
+			verb, result.ReadMarks, result.UnreadMarks, result.NotifiedMarks, result.ArchivedItems, result.CacheEntries)
+		return nil
+	},
+}
+
+// parseAge parses a Go duration, or a whole number of days (d), weeks (w)
+// or years (y)
+func parseAge(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+		"y": 365 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if n, err := strconv.Atoi(strings.TrimSuffix(s, suffix)); err == nil && strings.HasSuffix(s, suffix) {
+			if n <= 0 {
+				return 0, fmt.Errorf("age must be positive: %q", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("age must be positive: %q", s)
+	}
+	return d, nil
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().StringVar(&pruneOlderThan, "older-than", "1y", "remove entries older than this, e.g. 90d or 1y")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "only report what would be removed")
+}
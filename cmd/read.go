@@ -2,23 +2,44 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
-	"informant/internal/config"
 	"informant/internal/feed"
+	"informant/internal/i18n"
+	"informant/internal/logging"
 	"informant/internal/storage"
+	"informant/internal/tui"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var (
-	readAll bool
+	readAll      bool
+	readMarkdown bool
+	readFormat   string
+	readConfirm  bool
+	readMarkRead bool
+
+	// readTemplate is parsed from --format
+	readTemplate *template.Template
 )
 
+// readTemplateData is what a --format template is executed with: the item
+// fields, plus its content as it would be displayed
+type readTemplateData struct {
+	feed.Item
+	Rendered string
+}
+
+// readMarkdownWidth is the width Markdown is wrapped to when $COLUMNS does
+// not give the terminal width
+const readMarkdownWidth = 80
+
 // readCmd represents the read command
 var readCmd = &cobra.Command{
 	Use:   "read [item]",
@@ -28,58 +49,67 @@ var readCmd = &cobra.Command{
 - String matching the title
 
 If no item is specified, will loop through all unread items with prompts.
-Use --all to mark all items as read without displaying them.`,
+With --plain, all unread items are shown and marked as read without prompts.
+With --accessible, each item is announced as "Item N of M" and followed by an
+end marker, and the pager is never offered.
+Use --all to mark all items as read without displaying them.
+
+An item is only marked as read once its content has been shown in full: not
+when the pager fails or input ends at the pager prompt. With --confirm-mark,
+or after reading it in the pager, which may have been quit early, reading a
+specific item asks before marking it as read.
+
+With --format, each item is printed with a Go text/template instead, for
+piping into other tools, and unread items are not prompted for. Printed items
+stay unread unless --mark-read is given. The template
+gets the item fields (.Title, .Published, .Link, .FeedName, .Author, .Tags,
+.Content, ...) and .Rendered, the content as it would be displayed. The
+"date" function formats a time in the display timezone and "json" encodes a
+value, e.g. --format '{{date .Published "2006-01-02"}} {{.Title}}'.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		if readFormat != "" {
+			tmpl, err := template.New("read").Funcs(template.FuncMap{
+				"date": tui.FormatTime,
+				"json": func(v interface{}) (string, error) {
+					data, err := json.Marshal(v)
+					return string(data), err
+				},
+			}).Parse(readFormat)
+			if err != nil {
+				return fmt.Errorf("invalid --format template: %w", err)
+			}
+			readTemplate = tmpl
+		}
+
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		store, err := storage.NewWithConfirmation(!viper.GetBool("no-confirm"))
+		store, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
 		// Collect all items
-		var allItems []feed.Item
-		for _, feedCfg := range cfg.Feeds {
-			items, err := feed.ParseFeedWithStorage(feedCfg.URL, store)
-			if err != nil {
-				if viper.GetBool("verbose") {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to parse feed %s: %v\n", feedCfg.Name, err)
-				}
-				continue
-			}
-
-			for i := range items {
-				items[i].FeedName = feedCfg.Name
-			}
-
-			allItems = append(allItems, items...)
-		}
+		allItems := withArchived(cfg, store, loadItems(cfg, store))
 
 		// Sort by published date (newest first)
 		// This matches the order shown in 'list' command
-		for i := 0; i < len(allItems)-1; i++ {
-			for j := i + 1; j < len(allItems); j++ {
-				if allItems[i].Published.Before(allItems[j].Published) {
-					allItems[i], allItems[j] = allItems[j], allItems[i]
-				}
-			}
-		}
+		sortByPublished(allItems, false)
 
 		if readAll {
 			// Mark all items as read without displaying
 			count := 0
 			for _, item := range allItems {
-				if !store.IsRead(item.ID) {
-					if err := store.MarkAsRead(item.ID); err != nil {
+				if !store.IsRead(item.Key()) {
+					if err := store.MarkAsRead(item.Key()); err != nil {
 						return fmt.Errorf("failed to mark item as read: %w", err)
 					}
 					count++
 				}
 			}
-			fmt.Printf("Marked %d items as read.\n", count)
+			fmt.Println(i18n.T("Marked %d items as read.", count))
 			return nil
 		}
 
@@ -95,98 +125,234 @@ Use --all to mark all items as read without displaying them.`,
 
 func readUnreadInteractive(allItems []feed.Item, store *storage.Storage) error {
 	reader := bufio.NewReader(os.Stdin)
-	unreadFound := false
 
+	var unreadItems []feed.Item
 	for _, item := range allItems {
-		if store.IsRead(item.ID) {
+		if !store.IsRead(item.Key()) {
+			unreadItems = append(unreadItems, item)
+		}
+	}
+
+	for i, item := range unreadItems {
+		// Templated output is for other tools, so it is never interrupted
+		if readTemplate != nil {
+			if err := renderItem(item); err != nil {
+				return err
+			}
+			if readMarkRead {
+				if err := store.MarkAsRead(item.Key()); err != nil {
+					return fmt.Errorf("failed to mark item as read: %w", err)
+				}
+			}
 			continue
 		}
 
-		unreadFound = true
-		displayItem(item)
+		// Screen readers get explicit boundaries instead of visual ones
+		if isAccessible() {
+			fmt.Println(i18n.T("Item %d of %d", i+1, len(unreadItems)))
+		}
+		if shown, _ := displayItem(reader, item); !shown {
+			// Input ended or the pager failed, so the item was not read
+			return nil
+		}
+		if isAccessible() {
+			fmt.Println(i18n.T("End of item %d of %d", i+1, len(unreadItems)))
+		}
 
-		fmt.Print("\nMark as read and continue? [Y/n]: ")
+		// Plain mode never prompts; showing an item marks it as read
+		if isPlain() {
+			if err := store.MarkAsRead(item.Key()); err != nil {
+				return fmt.Errorf("failed to mark item as read: %w", err)
+			}
+			fmt.Println()
+			continue
+		}
+
+		fmt.Print("\n" + i18n.T("Mark as read and continue? [Y/n]: "))
 		response, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
 		}
 
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response == "" || response == "y" || response == "yes" {
-			if err := store.MarkAsRead(item.ID); err != nil {
+		if strings.TrimSpace(response) == "" || i18n.IsYes(response) {
+			if err := store.MarkAsRead(item.Key()); err != nil {
 				return fmt.Errorf("failed to mark item as read: %w", err)
 			}
-			fmt.Println("Marked as read.")
+			fmt.Println(i18n.T("Marked as read."))
 		} else {
-			fmt.Println("Skipped.")
+			fmt.Println(i18n.T("Skipped."))
 		}
 		fmt.Println()
 	}
 
-	if !unreadFound {
-		fmt.Println("No unread news items found.")
-		fmt.Println("Use 'informant list' to see all items or 'informant list --unread' to see only unread items.")
+	if len(unreadItems) == 0 && readTemplate == nil {
+		fmt.Println(i18n.T("No unread news items found."))
+		fmt.Println(i18n.T("Use 'informant list' to see all items or 'informant list --unread' to see only unread items."))
 	}
 
 	return nil
 }
 
 func readSpecificItem(itemRef string, allItems []feed.Item, store *storage.Storage) error {
-	var targetItem *feed.Item
+	targetItem, err := findItem(itemRef, allItems)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	confirm := readConfirm
+	if readTemplate != nil {
+		if err := renderItem(*targetItem); err != nil {
+			return err
+		}
+		// Printing for another tool is not reading
+		if !readMarkRead {
+			return nil
+		}
+	} else {
+		shown, paged := displayItem(reader, *targetItem)
+		if !shown {
+			return nil
+		}
+		// Quitting the pager early looks just like reading to the end
+		confirm = confirm || paged
+	}
 
+	if confirm {
+		fmt.Print("\n" + i18n.T("Mark as read? [Y/n]: "))
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			// Without an answer the item stays unread
+			fmt.Println()
+			return nil
+		}
+		if strings.TrimSpace(response) != "" && !i18n.IsYes(response) {
+			fmt.Println(i18n.T("Skipped."))
+			return nil
+		}
+	}
+
+	if err := store.MarkAsRead(targetItem.Key()); err != nil {
+		return fmt.Errorf("failed to mark item as read: %w", err)
+	}
+	if confirm {
+		fmt.Println(i18n.T("Marked as read."))
+	}
+
+	return nil
+}
+
+// findItem looks up an item by its index as shown by 'informant list', or
+// by a case-insensitive substring of its title
+func findItem(itemRef string, allItems []feed.Item) (*feed.Item, error) {
 	// Try to parse as index first
 	if index, err := strconv.Atoi(itemRef); err == nil {
 		if index >= 1 && index <= len(allItems) {
-			targetItem = &allItems[index-1]
+			return &allItems[index-1], nil
 		}
 	} else {
 		// Search by title
-		itemRef = strings.ToLower(itemRef)
+		ref := strings.ToLower(itemRef)
 		for i, item := range allItems {
-			if strings.Contains(strings.ToLower(item.Title), itemRef) {
-				targetItem = &allItems[i]
-				break
+			if strings.Contains(strings.ToLower(item.Title), ref) {
+				return &allItems[i], nil
 			}
 		}
 	}
 
-	if targetItem == nil {
-		return fmt.Errorf("item not found: %s", itemRef)
-	}
-
-	displayItem(*targetItem)
+	return nil, fmt.Errorf("item not found: %s", itemRef)
+}
 
-	if err := store.MarkAsRead(targetItem.ID); err != nil {
-		return fmt.Errorf("failed to mark item as read: %w", err)
+// displayItem prints an item, offering the pager for long content. It
+// returns whether the content was shown in full, so the item can be marked as
+// read: not when writing it failed, input ended at the pager prompt or the
+// pager failed. paged is set when the content was shown in the pager.
+func displayItem(reader *bufio.Reader, item feed.Item) (shown, paged bool) {
+	titleStyle := tui.CLIUnreadStyle
+	if item.Highlighted {
+		titleStyle = tui.CLIHighlightStyle
 	}
 
-	return nil
-}
-
-func displayItem(item feed.Item) {
-	fmt.Printf("Title: %s\n", item.Title)
-	fmt.Printf("Date: %s\n", item.Published.Format("2006-01-02 15:04:05"))
+	fmt.Printf("%s %s\n", paint(tui.CLILabelStyle, i18n.T("Title:")), paint(titleStyle, item.Title))
+	fmt.Printf("%s %s\n", paint(tui.CLILabelStyle, i18n.T("Date:")), paint(tui.CLIDateStyle, tui.FormatTime(item.Published, "2006-01-02 15:04:05")))
 	if item.FeedName != "" {
-		fmt.Printf("Feed: %s\n", item.FeedName)
+		fmt.Printf("%s %s\n", paint(tui.CLILabelStyle, i18n.T("Feed:")), paint(tui.CLIFeedNameStyle, item.FeedName))
+	}
+	content := itemContent(item)
+	if _, err := fmt.Printf("\n%s\n", content); err != nil {
+		return false, false
 	}
-	fmt.Printf("\n%s\n", item.Content)
 
 	// Check if content is long and offer pager
-	lines := strings.Count(item.Content, "\n")
-	if lines > 20 {
-		fmt.Print("\nPress Enter to continue or 'p' to view in pager: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
+	lines := strings.Count(content, "\n")
+	if lines > 20 && !isPlain() && !isAccessible() {
+		fmt.Print("\n" + i18n.T("Press Enter to continue or 'p' to view in pager: "))
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println()
+			return false, false
+		}
 		response = strings.TrimSpace(strings.ToLower(response))
 
 		if response == "p" {
-			showInPager(fmt.Sprintf("Title: %s\nDate: %s\nFeed: %s\n\n%s",
-				item.Title, item.Published.Format("2006-01-02 15:04:05"), item.FeedName, item.Content))
+			return showInPager(fmt.Sprintf("%s %s\n%s %s\n%s %s\n\n%s",
+				i18n.T("Title:"), item.Title, i18n.T("Date:"), tui.FormatTime(item.Published, "2006-01-02 15:04:05"),
+				i18n.T("Feed:"), item.FeedName, content)), true
 		}
 	}
+	return true, false
 }
 
-func showInPager(content string) {
+// renderItem prints an item with the --format template, ending it with a
+// newline if the template does not
+func renderItem(item feed.Item) error {
+	var b strings.Builder
+	if err := readTemplate.Execute(&b, readTemplateData{Item: item, Rendered: itemContent(item)}); err != nil {
+		return fmt.Errorf("failed to render item: %w", err)
+	}
+
+	output := b.String()
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// itemContent returns the content shown for an item, rendered from Markdown
+// with --markdown. Otherwise links are marked [N] in the text and listed
+// after it, so they can be followed from the terminal.
+func itemContent(item feed.Item) string {
+	if !readMarkdown || item.Markdown == "" {
+		content, links := item.ContentWithFootnotes()
+		if len(links) == 0 {
+			return content
+		}
+
+		var b strings.Builder
+		b.WriteString(content + "\n\n" + paint(tui.CLILabelStyle, i18n.T("Links:")))
+		for i, link := range links {
+			fmt.Fprintf(&b, "\n[%d] %s", i+1, link)
+		}
+		return b.String()
+	}
+
+	width := readMarkdownWidth
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+
+	rendered, err := tui.RenderMarkdown(item.Markdown, width, !colorEnabled())
+	if err != nil {
+		logging.Warnf("Failed to render item as Markdown: %v", err)
+		return item.Content
+	}
+	return rendered
+}
+
+// showInPager pages content and returns whether the pager exited normally.
+// When the pager cannot be started, content is printed instead.
+func showInPager(content string) bool {
 	// Try to use system pager
 	pager := os.Getenv("PAGER")
 	if pager == "" {
@@ -198,14 +364,26 @@ func showInPager(content string) {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	if err := cmd.Run(); err != nil {
-		// Fallback to simple output if pager fails
-		fmt.Print(content)
+	if err := cmd.Start(); err != nil {
+		// Fallback to simple output if there is no pager
+		_, err := fmt.Print(content)
+		return err == nil
+	}
+	// A pager killed or failing, e.g. as the terminal closed, did not show
+	// all of the content
+	if err := cmd.Wait(); err != nil {
+		logging.Warnf("Pager exited with an error, item not marked as read: %v", err)
+		return false
 	}
+	return true
 }
 
 func init() {
 	rootCmd.AddCommand(readCmd)
 
 	readCmd.Flags().BoolVar(&readAll, "all", false, "mark all items as read without displaying them")
+	readCmd.Flags().BoolVar(&readMarkdown, "markdown", false, "render item content as styled Markdown")
+	readCmd.Flags().BoolVar(&readConfirm, "confirm-mark", false, "ask before marking a specific item as read")
+	readCmd.Flags().StringVar(&readFormat, "format", "", "print items with this Go template instead, e.g. '{{.Title}}: {{.Link}}'")
+	readCmd.Flags().BoolVar(&readMarkRead, "mark-read", false, "with --format, mark the printed items as read")
 }
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestReplayUsesTemporaryStorage(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("..", "internal", "feed", "testdata", "archnews.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayDir := t.TempDir()
+	name := fmt.Sprintf("%x.feed", sha256.Sum256([]byte("https://archlinux.org/feeds/news/")))
+	if err := os.WriteFile(filepath.Join(replayDir, name), body, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keep the real config and read status out of reach
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Cleanup(viper.Reset)
+
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	rootCmd.SetArgs([]string{"--replay", replayDir, "--no-system-config", "--plain", "--quiet", "list"})
+	err = Execute()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	filepath.Walk(home, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Name() == ".informant_read_status.json" {
+			t.Errorf("replay wrote the per-user read status %s", path)
+		}
+		return nil
+	})
+	storagePath := viper.GetString("storage-path")
+	if storagePath == "" {
+		t.Fatal("replay did not use a storage path of its own")
+	}
+	if _, err := os.Stat(filepath.Dir(storagePath)); !os.IsNotExist(err) {
+		t.Errorf("temporary read status %s was not removed: %v", storagePath, err)
+	}
+}
@@ -3,15 +3,25 @@ package cmd
 import (
 	"fmt"
 	"informant/internal/config"
+	"informant/internal/feed"
+	"informant/internal/i18n"
+	"informant/internal/logging"
+	"informant/internal/storage"
+	"informant/internal/tui"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile string
-	version = "1.4.1" // Matching original version
+	cfgFile      string
+	configLoaded bool
+	version      = "1.4.1" // Matching original version
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -24,11 +34,59 @@ It can interrupt pacman transactions to ensure you have read the news first.
 informant provides commands to check, list, and read news items, plus an
 interactive TUI mode for browsing news.`,
 	Version: version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := configureLogging(cmd); err != nil {
+			return err
+		}
+		if err := configureFixtures(cmd); err != nil {
+			return err
+		}
+		if err := i18n.SetLanguage(i18n.Detect(viper.GetString("language"))); err != nil {
+			logging.Warnf("Failed to load translations: %v", err)
+		}
+		if err := configureTimezone(); err != nil {
+			return err
+		}
+		return configureColor()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	finish()
+	return err
+}
+
+// openedStores are the storages opened by the command, synced when it
+// finishes
+var openedStores []*storage.Storage
+
+// finish syncs the read status changed by the command, once, when auto-sync
+// is enabled, removes the temporary read status of a replay and closes the
+// log file
+func finish() {
+	// The temporary read status of a replay is not for the remote
+	if replayStorageDir != "" {
+		os.RemoveAll(replayStorageDir)
+		replayStorageDir = ""
+		openedStores = nil
+	}
+
+	for _, store := range openedStores {
+		store.AutoSync()
+	}
+	openedStores = nil
+
+	// Last, so that sync failures are still logged
+	logging.Close()
+}
+
+// exit finishes the command and exits with code, for commands whose exit
+// status is their result
+func exit(code int) {
+	finish()
+	os.Exit(code)
 }
 
 func init() {
@@ -36,47 +94,391 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.informantrc.json)")
+	rootCmd.PersistentFlags().Bool("no-system-config", false, "do not merge "+systemConfigFile+" under the user config")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "do not show feed fetch progress")
 	rootCmd.PersistentFlags().Bool("no-confirm", false, "skip confirmation prompts for storage fallback")
+	rootCmd.PersistentFlags().Bool("plain", false, "non-interactive mode: no prompts, no pager, no styling")
+	rootCmd.PersistentFlags().Bool("accessible", false, "screen-reader-friendly output: linear, unstyled, with explicit item markers")
+	rootCmd.PersistentFlags().String("color", "auto", "colorize output: auto, always or never")
+	rootCmd.PersistentFlags().Bool("no-color", false, "disable colors and use ASCII glyphs, like --color never (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().String("log-level", "warn", "minimum level of logged messages: debug, info, warn or error")
+	rootCmd.PersistentFlags().String("log-file", "", "also append log messages to this file")
+	rootCmd.PersistentFlags().String("log-format", logging.FormatText, "log output format: text or json")
+	rootCmd.PersistentFlags().String("profile", "", "only use the feeds of this profile from the config")
+	rootCmd.PersistentFlags().String("timezone", "", "show timestamps in this timezone: local or a name like Europe/Berlin (default as published)")
+	rootCmd.PersistentFlags().String("storage-path", "", "read status file to use instead of the system-wide or per-user one")
 
 	// Bind flags to viper
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	viper.BindPFlag("no-confirm", rootCmd.PersistentFlags().Lookup("no-confirm"))
+	bindFlags(viper.GetViper())
+
+	// Record and replay raw feed responses, for testing without network access
+	rootCmd.PersistentFlags().String("record", "", "save raw feed responses to this directory")
+	rootCmd.PersistentFlags().String("replay", "", "read feed responses from this directory instead of the network")
+	rootCmd.PersistentFlags().MarkHidden("record")
+	rootCmd.PersistentFlags().MarkHidden("replay")
 }
 
+// boundFlags are the global flags that override settings of the same name
+var boundFlags = []string{
+	"no-system-config", "verbose", "quiet", "no-confirm", "plain", "accessible", "color", "no-color",
+	"log-level", "log-file", "log-format", "profile", "timezone", "storage-path",
+}
+
+// bindFlags binds the global flags to the settings of v
+func bindFlags(v *viper.Viper) {
+	for _, name := range boundFlags {
+		v.BindPFlag(name, rootCmd.PersistentFlags().Lookup(name))
+	}
+}
+
+// systemConfigFile is the system-wide config, merged under the user's
+const systemConfigFile = "/etc/informantrc.json"
+
 // initConfig reads in config file and ENV variables.
 func initConfig() {
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
+	// Read in INFORMANT_ environment variables that override settings
+	config.BindEnv(viper.GetViper())
+
+	if readConfigLayers(viper.GetViper()) {
+		configLoaded = true
 	} else {
-		// Search config in home directory and standard locations
-		home, err := os.UserHomeDir()
+		// Initialize default config if no config file found
+		config.SetDefaults(viper.GetViper())
+	}
+}
+
+// readConfigLayers reads the system-wide config into v and merges the user's
+// config (from --config or the first one found) over it. Settings from the
+// user's config take precedence; its feeds are added to the system feeds,
+// replacing those with the same name or URL. It reports whether any config
+// was read.
+func readConfigLayers(v *viper.Viper) bool {
+	userFile := cfgFile
+	if userFile == "" {
+		userFile = findUserConfig()
+	}
+
+	systemLoaded := false
+	if !v.GetBool("no-system-config") && userFile != systemConfigFile {
+		if _, err := os.Stat(systemConfigFile); err == nil {
+			v.SetConfigFile(systemConfigFile)
+			if err := v.ReadInConfig(); err != nil {
+				logging.Warnf("Failed to read system config %s: %v", systemConfigFile, err)
+			} else {
+				systemLoaded = true
+			}
+		}
+	}
+	if userFile == "" {
+		return systemLoaded
+	}
+
+	systemFeeds, _ := v.Get("feeds").([]interface{})
+	v.SetConfigFile(userFile)
+	if !systemLoaded {
+		return v.ReadInConfig() == nil
+	}
+	if err := v.MergeInConfig(); err != nil {
+		logging.Warnf("Failed to read config %s: %v", userFile, err)
+		return true
+	}
+
+	if userFeeds, ok := v.Get("feeds").([]interface{}); ok && len(systemFeeds) > 0 {
+		v.MergeConfigMap(map[string]interface{}{"feeds": mergeFeeds(systemFeeds, userFeeds)})
+	}
+	return true
+}
+
+// reloadConfig reads the config files again into a fresh viper, leaving the
+// global one alone since other goroutines read it
+func reloadConfig() (*config.Config, error) {
+	v := viper.New()
+	config.BindEnv(v)
+	bindFlags(v)
+	if !readConfigLayers(v) {
+		config.SetDefaults(v)
+	}
+	return config.LoadFrom(v)
+}
+
+// findUserConfig returns the first user config file found in the home
+// directory or $XDG_CONFIG_HOME. Unlike the original informant, the current
+// directory is not searched: a config there could run commands through
+// exec: feeds, for example from the pacman hook run in any directory.
+func findUserConfig() string {
+	var dirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	} else {
+		logging.Errorf("Error getting home directory: %v", err)
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		dirs = append(dirs, xdg)
+	}
+
+	for _, dir := range dirs {
+		for _, name := range []string{".informantrc.json", "informantrc.json"} {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// mergeFeeds adds the user's feeds to the system feeds. A user feed with
+// the same name or URL as a system feed replaces it.
+func mergeFeeds(systemFeeds, userFeeds []interface{}) []interface{} {
+	merged := append([]interface{}{}, systemFeeds...)
+	for _, userFeed := range userFeeds {
+		replaced := false
+		for i, systemFeed := range merged {
+			if sameFeed(systemFeed, userFeed) {
+				merged[i] = userFeed
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, userFeed)
+		}
+	}
+	return merged
+}
+
+// sameFeed reports whether two raw feed entries share a name or URL
+func sameFeed(a, b interface{}) bool {
+	fa, okA := a.(map[string]interface{})
+	fb, okB := b.(map[string]interface{})
+	if !okA || !okB {
+		return false
+	}
+	for _, key := range []string{"name", "url"} {
+		if va, ok := fa[key].(string); ok && va != "" && va == fb[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// configureLogging sets up the logger from --log-level, --log-format and
+// --log-file.
+// --verbose raises the level to info unless a level was given explicitly.
+func configureLogging(cmd *cobra.Command) error {
+	level, err := logging.ParseLevel(viper.GetString("log-level"))
+	if err != nil {
+		return err
+	}
+	if viper.GetBool("verbose") && !cmd.Flags().Changed("log-level") && !viper.InConfig("log-level") && level > logging.LevelInfo {
+		level = logging.LevelInfo
+	}
+
+	if err := logging.Setup(level, viper.GetString("log-format"), viper.GetString("log-file")); err != nil {
+		return err
+	}
+
+	if configLoaded {
+		logging.Infof("Using config file: %s", viper.ConfigFileUsed())
+	}
+	return nil
+}
+
+// replayStorageDir holds the read status of a replay, removed when the
+// command finishes
+var replayStorageDir string
+
+// configureFixtures sets up recording or replaying of feed responses from
+// the hidden --record and --replay flags
+func configureFixtures(cmd *cobra.Command) error {
+	recordDir, _ := cmd.Flags().GetString("record")
+	replayDir, _ := cmd.Flags().GetString("replay")
+	if recordDir != "" && replayDir != "" {
+		return fmt.Errorf("--record and --replay cannot be combined")
+	}
+
+	feed.SetRecordDir(recordDir)
+	feed.SetReplayDir(replayDir)
+
+	// Replayed news must not be marked in the real read status
+	if replayDir != "" && viper.GetString("storage-path") == "" {
+		dir, err := os.MkdirTemp("", "informant-replay-")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
-			return
+			return fmt.Errorf("failed to create replay storage: %w", err)
 		}
+		replayStorageDir = dir
+		viper.Set("storage-path", filepath.Join(dir, "read-status.json"))
+	}
+	return nil
+}
 
-		// Search config in multiple locations as per original informant
-		viper.AddConfigPath(home)
-		viper.AddConfigPath("$XDG_CONFIG_HOME")
-		viper.AddConfigPath("/etc")
-		viper.AddConfigPath(".")
-		viper.SetConfigType("json")
-		viper.SetConfigName(".informantrc")
-		viper.SetConfigName("informantrc")
+// configureTimezone sets the timezone timestamps are shown in from
+// --timezone or "timezone" in the config
+func configureTimezone() error {
+	loc, err := config.GetTimezone()
+	if err != nil {
+		return err
+	}
+	tui.SetTimezone(loc)
+	return nil
+}
+
+// loadConfig loads the configuration and applies fetcher settings from it
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := applyConfig(cfg); err != nil {
+		return nil, err
 	}
+	return cfg, nil
+}
 
-	// Read in environment variables that match
-	viper.AutomaticEnv()
+// fetchMu keeps applyConfig from changing the fetcher settings while
+// fetchItems runs, for commands that reload the config
+var fetchMu sync.RWMutex
 
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		if viper.GetBool("verbose") {
-			fmt.Fprintf(os.Stderr, "Using config file: %s\n", viper.ConfigFileUsed())
+// applyConfig applies the fetcher settings of cfg. They are only changed
+// once all of them are valid, so a failed reload keeps the previous ones.
+func applyConfig(cfg *config.Config) error {
+	clients := make(map[string]*http.Client)
+	for _, feedCfg := range cfg.Feeds {
+		if !feedCfg.HasTLSOptions() {
+			continue
 		}
-	} else {
-		// Initialize default config if no config file found
-		config.SetDefaults()
+		client, err := feed.NewTLSClient(feed.TLSOptions{
+			CACert:             feedCfg.CACert,
+			ClientCert:         feedCfg.ClientCert,
+			ClientKey:          feedCfg.ClientKey,
+			InsecureSkipVerify: feedCfg.InsecureSkipVerify,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to configure TLS for feed %q: %w", feedName(feedCfg), err)
+		}
+		clients[feedCfg.URL] = client
 	}
+
+	fetchMu.Lock()
+	defer fetchMu.Unlock()
+
+	feed.MaxResponseSize = cfg.MaxFeedSize
+	feed.CacheTTL = cfg.CacheTTL
+	feed.CacheMinTTL = cfg.CacheMinTTL
+	feed.CacheMaxTTL = cfg.CacheMaxTTL
+	feed.HostMaxConcurrency = cfg.HostMaxConcurrency
+	feed.HostMinInterval = cfg.HostMinInterval
+
+	for _, plugin := range cfg.Plugins {
+		switch plugin.Type {
+		case config.PluginFetcher:
+			feed.RegisterFetcher(plugin.Scheme, feed.ExternalFetcher{Command: plugin.Command})
+		case config.PluginProcessor:
+			feed.RegisterProcessor(feed.ExternalProcessor{ProcessorName: plugin.Name, Command: plugin.Command})
+		}
+	}
+
+	feed.SetTLSClients(clients)
+	return nil
+}
+
+// isPlain reports whether non-interactive plain mode is enabled
+func isPlain() bool {
+	return viper.GetBool("plain")
+}
+
+// isAccessible reports whether screen-reader-friendly output is enabled:
+// linear, unstyled output without pagers or full-screen interfaces
+func isAccessible() bool {
+	return viper.GetBool("accessible")
+}
+
+// openStorage opens the read status storage, only prompting about the
+// per-user fallback when prompts are allowed
+func openStorage() (*storage.Storage, error) {
+	store, err := storage.NewWithConfirmation(!viper.GetBool("no-confirm") && !isPlain())
+	if err != nil {
+		return nil, err
+	}
+	openedStores = append(openedStores, store)
+	return store, nil
+}
+
+// watchConfigChanges watches the config files read, the user's and the
+// system-wide one, and sends the config read again after every change on
+// the returned channel, to be applied with applyConfig. A pending config is
+// replaced by a newer one, and broken configs are skipped with a warning.
+// The channel is nil when no config file was loaded or none can be watched,
+// so receiving from it blocks forever.
+func watchConfigChanges() <-chan *config.Config {
+	if !configLoaded {
+		return nil
+	}
+
+	paths := map[string]bool{filepath.Clean(viper.ConfigFileUsed()): true}
+	if !viper.GetBool("no-system-config") {
+		if _, err := os.Stat(systemConfigFile); err == nil {
+			paths[systemConfigFile] = true
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logging.Warnf("Failed to watch config file: %v", err)
+		return nil
+	}
+	// Editors often replace the file instead of writing to it, so its
+	// directory is watched
+	watched := make(map[string]bool)
+	for path := range paths {
+		dir := filepath.Dir(path)
+		if watched[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			logging.Warnf("Failed to watch config file %s: %v", path, err)
+			continue
+		}
+		watched[dir] = true
+	}
+	if len(watched) == 0 {
+		watcher.Close()
+		return nil
+	}
+
+	changed := make(chan *config.Config, 1)
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !paths[filepath.Clean(event.Name)] || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				logging.Event(logging.LevelInfo, "Config file changed", logging.Fields{"path": event.Name})
+
+				cfg, err := reloadConfig()
+				if err != nil {
+					logging.Warnf("Failed to reload config, keeping the previous one: %v", err)
+					continue
+				}
+				// Only this goroutine sends, so there is room after draining
+				select {
+				case <-changed:
+				default:
+				}
+				changed <- cfg
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logging.Warnf("Failed to watch config file: %v", err)
+			}
+		}
+	}()
+
+	return changed
 }
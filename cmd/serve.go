@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"informant/internal/feed"
+	"informant/internal/logging"
+	"informant/internal/server"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveListen string
+	serveSocket string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a local HTTP API for news items",
+	Long: `Serve a small REST API so desktop widgets and other tools can query and
+update news items without shelling out repeatedly.
+
+Endpoints:
+- GET  /api/items[?unread=true]  List items (indexes match 'informant list')
+- GET  /api/items/{index}        Get a single item including its content
+- POST /api/items/{index}/read   Mark an item as read
+- POST /api/items/{index}/unread Mark an item as unread
+- GET  /api/unread-count         Number of unread items
+- GET  /feed.xml[?unread=true]    All feeds merged into one RSS feed
+- GET  /metrics                   Prometheus metrics
+
+By default the API listens on localhost only. Use --socket to listen on a unix
+socket instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		listener, err := listen(serveListen, serveSocket)
+		if err != nil {
+			return err
+		}
+
+		// The items of the last load are kept for /metrics, so that scrapes
+		// do not fetch the feeds
+		var lastMu sync.Mutex
+		var lastItems []feed.Item
+		var loaded bool
+		load := func() []feed.Item {
+			items, _ := fetchItems(context.Background(), cfg, store, false)
+			items = withArchived(cfg, store, items)
+
+			lastMu.Lock()
+			lastItems, loaded = items, true
+			lastMu.Unlock()
+			return items
+		}
+		lastLoad := func() []feed.Item {
+			lastMu.Lock()
+			items, ok := lastItems, loaded
+			lastMu.Unlock()
+			if !ok {
+				return load()
+			}
+			return items
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/", server.New(load, store))
+		mux.Handle("/metrics", metricsHandler(cfg, store, lastLoad))
+
+		srv := &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			srv.Shutdown(shutdownCtx)
+		}()
+
+		logging.Infof("Serving informant API on %s", listener.Addr())
+
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server error: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// listen opens a unix socket when socketPath is set, otherwise a TCP listener
+func listen(addr, socketPath string) (net.Listener, error) {
+	if socketPath == "" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+		return listener, nil
+	}
+
+	// Remove a stale socket left behind by a previous run
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveListen, "listen", "127.0.0.1:8737", "TCP address to listen on")
+	serveCmd.Flags().StringVar(&serveSocket, "socket", "", "unix socket path to listen on instead of TCP")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"informant/internal/i18n"
+	"informant/internal/tui"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// snoozeCmd represents the snooze command
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <item> <duration>",
+	Short: "Hide a news item from check and unread counts for a while",
+	Long: `Snooze a news item, e.g. news that requires action you cannot take until
+the weekend. Until the snooze expires, the item is left out of 'informant
+check', the pacman hook and unread counts, while it is still listed. The item
+is specified like for 'informant star'.
+
+Durations are Go durations such as "36h", or a number of days, weeks or years
+such as "3d", "2w" or "1y".`,
+	Example: `  informant snooze 1 3d
+  informant snooze "manual intervention" 2w`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		duration, err := parseAge(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		return setSnoozed(args[0], time.Now().Add(duration))
+	},
+}
+
+// unsnoozeCmd represents the unsnooze command
+var unsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze <item>",
+	Short: "End the snooze of a news item",
+	Long: `End the snooze of a news item right away. The item is specified like for
+'informant star'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSnoozed(args[0], time.Time{})
+	},
+}
+
+// setSnoozed snoozes the item referenced by itemRef until the given time, or
+// ends its snooze for a zero time
+func setSnoozed(itemRef string, until time.Time) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Number items the same way as 'list'
+	allItems := withArchived(cfg, store, loadItems(cfg, store))
+	sortByPublished(allItems, false)
+
+	item, err := findItem(itemRef, allItems)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Snooze(item.Key(), until); err != nil {
+		return fmt.Errorf("failed to update snoozed items: %w", err)
+	}
+
+	if until.IsZero() {
+		fmt.Println(i18n.T("Unsnoozed: %s", item.Title))
+	} else {
+		fmt.Println(i18n.T("Snoozed until %s: %s", tui.FormatTime(until, "2006-01-02 15:04"), item.Title))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+	rootCmd.AddCommand(unsnoozeCmd)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"informant/internal/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+// starCmd represents the star command
+var starCmd = &cobra.Command{
+	Use:   "star <item>",
+	Short: "Star a news item",
+	Long: `Star a news item so it can be retrieved later with 'informant list --starred'.
+You can specify the item by:
+- Index number (as shown in 'informant list')
+- String matching the title
+
+Starred items stay available from the offline archive after they have been
+dropped from their feed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStarred(args[0], true)
+	},
+}
+
+// unstarCmd represents the unstar command
+var unstarCmd = &cobra.Command{
+	Use:   "unstar <item>",
+	Short: "Remove the star from a news item",
+	Long: `Remove the star from a news item. The item is specified like for
+'informant star'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setStarred(args[0], false)
+	},
+}
+
+// setStarred stars or unstars the item referenced by itemRef
+func setStarred(itemRef string, starred bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// Number items the same way as 'list'
+	allItems := withArchived(cfg, store, loadItems(cfg, store))
+	sortByPublished(allItems, false)
+
+	item, err := findItem(itemRef, allItems)
+	if err != nil {
+		return err
+	}
+
+	if err := store.SetStarred(item.Key(), starred); err != nil {
+		return fmt.Errorf("failed to update starred items: %w", err)
+	}
+
+	if starred {
+		fmt.Println(i18n.T("Starred: %s", item.Title))
+	} else {
+		fmt.Println(i18n.T("Unstarred: %s", item.Title))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(starCmd)
+	rootCmd.AddCommand(unstarCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusStaleAfter time.Duration
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the health of each configured feed",
+	Long: `Show for each configured feed when it was last fetched successfully, the
+last error, and how many items it has. Feeds are not fetched; the status is
+recorded by every command that fetches them, such as 'check' and 'watch'.
+
+A feed is reported as stale when its last successful fetch is older than
+--stale-after.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		// Count unread items per feed from the archive, which holds every
+		// item seen so far
+		unread := make(map[string]int)
+		for _, item := range store.ArchivedItems() {
+			if isPending(store, item) {
+				unread[item.FeedURL]++
+			}
+		}
+
+		for i, feedCfg := range cfg.Feeds {
+			if i > 0 {
+				fmt.Println()
+			}
+
+			name := feedCfg.Name
+			if name == "" {
+				name = feedCfg.URL
+			}
+			fmt.Printf("%s (%s)\n", name, feedCfg.URL)
+
+			status, ok := store.GetFeedStatus(feedCfg.URL)
+			if !ok {
+				fmt.Println("  Status:       never fetched")
+				continue
+			}
+
+			health := "ok"
+			switch {
+			case status.LastError != "":
+				health = "failing"
+			case time.Since(status.LastSuccess) > statusStaleAfter:
+				health = "stale"
+			}
+			fmt.Printf("  Status:       %s\n", health)
+
+			if status.LastSuccess.IsZero() {
+				fmt.Println("  Last success: never")
+			} else {
+				fmt.Printf("  Last success: %s (%s ago)\n",
+					status.LastSuccess.Format("2006-01-02 15:04:05"), time.Since(status.LastSuccess).Round(time.Minute))
+			}
+			if status.LastError != "" {
+				fmt.Printf("  Last error:   %s (%s)\n", status.LastError, status.LastErrorAt.Format("2006-01-02 15:04:05"))
+			}
+			fmt.Printf("  Items:        %d in feed, %d unread\n", status.ItemCount, unread[feedCfg.URL])
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().DurationVar(&statusStaleAfter, "stale-after", 24*time.Hour, "report feeds not fetched successfully for this long as stale")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync read status with the configured remote",
+	Long: `Pull the read status from the configured WebDAV or S3-compatible remote,
+merge it with the local read status and push the result back.
+
+For every item the most recent read or unread mark wins, so machines that
+share a remote agree on what has been read. Set "auto": true in the sync
+configuration to sync automatically whenever the read status changes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := loadConfig(); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		if !store.HasRemote() {
+			return fmt.Errorf("remote sync is not configured; add a \"sync\" section to your config")
+		}
+
+		result, err := store.Sync()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Synced read status (%d items updated from remote).\n", result.Pulled)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"informant/internal/tui"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/viper"
+)
+
+// configureColor validates --color and forces styling on when it is "always",
+// even if stdout is not a terminal. Without color, the TUI falls back to
+// plain styles and ASCII glyphs too.
+func configureColor() error {
+	switch viper.GetString("color") {
+	case "auto", "never":
+	case "always":
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	default:
+		return fmt.Errorf("invalid --color value %q: must be auto, always or never", viper.GetString("color"))
+	}
+
+	tui.SetPlain(noColor())
+	return nil
+}
+
+// noColor reports whether color was turned off, by --no-color, --color never
+// or the NO_COLOR environment variable (https://no-color.org). An explicit
+// --color always overrides NO_COLOR.
+func noColor() bool {
+	if viper.GetBool("no-color") || viper.GetString("color") == "never" {
+		return true
+	}
+	return os.Getenv("NO_COLOR") != "" && viper.GetString("color") != "always"
+}
+
+// colorEnabled reports whether command line output should be styled
+func colorEnabled() bool {
+	if isPlain() || isAccessible() || noColor() {
+		return false
+	}
+
+	if viper.GetString("color") == "always" {
+		return true
+	}
+	return isTerminal(os.Stdout)
+}
+
+// paint renders text with a style when color is enabled
+func paint(style lipgloss.Style, text string) string {
+	if !colorEnabled() {
+		return text
+	}
+	return style.Render(text)
+}
+
+// progressEnabled reports whether feed fetches should be reported on stderr:
+// only on a terminal, and not with --quiet or --plain
+func progressEnabled() bool {
+	return !viper.GetBool("quiet") && !isPlain() && isTerminal(os.Stderr)
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// summarize returns the first paragraph of content on a single line,
+// truncated to at most maxLen characters
+func summarize(content string, maxLen int) string {
+	paragraph := strings.SplitN(strings.TrimSpace(content), "\n\n", 2)[0]
+	summary := strings.Join(strings.Fields(paragraph), " ")
+
+	runes := []rune(summary)
+	if len(runes) > maxLen {
+		summary = strings.TrimSpace(string(runes[:maxLen-3])) + "..."
+	}
+	return summary
+}
@@ -1,16 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"informant/internal/config"
 	"informant/internal/feed"
+	"informant/internal/logging"
 	"informant/internal/storage"
 	"informant/internal/tui"
-	"os"
+	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // tuiCmd represents the tui command
@@ -23,56 +24,89 @@ news items. Use arrow keys or vim-style keys for navigation.
 Key bindings:
 - j/↓: Move down
 - k/↑: Move up
+- PgDn/PgUp, Ctrl+D/Ctrl+U: Move a page or half a page
+- g/Home, G/End: Go to the first/last item
+- n/Tab, p/Shift+Tab: Jump to the next/previous unread item
+- s: Cycle the sort order
+- b: Star/unstar, B: Show only starred items, A: Mark all listed items as read
+- z: Snooze for a day, l: Read later
+- e: Show feeds that failed, with r to retry one
 - Enter: Read selected item
 - r: Mark as read/unread
 - q: Quit
-- ?: Show help`,
+- ?: Show help (any key closes it)
+
+With tui-refresh-interval set in the config, feeds are fetched again in the
+background and newly arrived items are marked NEW.
+
+Changes to the config file, such as added feeds, are picked up while the
+TUI is open.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		if isPlain() {
+			return fmt.Errorf("the TUI is interactive and cannot be used with --plain")
+		}
+		if isAccessible() {
+			return fmt.Errorf("the TUI cannot be used with --accessible; use 'informant read' to go through unread items")
+		}
+
+		cfg, err := loadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		store, err := storage.NewWithConfirmation(!viper.GetBool("no-confirm"))
+		store, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		// Collect all items
-		var allItems []feed.Item
-		for _, feedCfg := range cfg.Feeds {
-			items, err := feed.ParseFeedWithStorage(feedCfg.URL, store)
-			if err != nil {
-				if viper.GetBool("verbose") {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to parse feed %s: %v\n", feedCfg.Name, err)
-				}
-				continue
-			}
-
-			for i := range items {
-				items[i].FeedName = feedCfg.Name
-			}
-
-			allItems = append(allItems, items...)
-		}
-
+		allItems := loadTUIItems(cfg, store, progressEnabled())
 		if len(allItems) == 0 {
 			return fmt.Errorf("no news items found")
 		}
 
-		// Sort by published date (newest first)
-		for i := 0; i < len(allItems)-1; i++ {
-			for j := i + 1; j < len(allItems); j++ {
-				if allItems[i].Published.Before(allItems[j].Published) {
-					allItems[i], allItems[j] = allItems[j], allItems[i]
-				}
-			}
-		}
-
 		// Initialize and run TUI
-		model := tui.NewModel(allItems, store)
+		model := tui.NewModel(allItems, store, feedNames(cfg), cfg.TUIColumns, cfg.ReaderFormat == config.ReaderFormatMarkdown)
+		model.SetFeedErrors(feedErrors(cfg, store))
+		// Auto-refresh and retries use the config last reloaded while the
+		// TUI is open
+		var latestMu sync.Mutex
+		latest := cfg
+		currentConfig := func() *config.Config {
+			latestMu.Lock()
+			defer latestMu.Unlock()
+			return latest
+		}
+		model.SetRetry(func(url string) tea.Msg {
+			return retryFeed(currentConfig(), url, store)
+		})
+		model.SetAutoRefresh(cfg.TUIRefreshInterval, func() tui.ItemsMsg {
+			return tuiItemsMsg(currentConfig(), store)
+		})
 		p := tea.NewProgram(model, tea.WithAltScreen())
 
+		// Look for a new release in the background, it may take a moment
+		go func() {
+			if notice := versionNotice(cfg, store); notice != "" {
+				p.Send(tui.NoticeMsg{Text: notice})
+			}
+		}()
+
+		// Reload feeds when the config file changes while the TUI is open
+		if configChanged := watchConfigChanges(); configChanged != nil {
+			go func() {
+				for newCfg := range configChanged {
+					if err := applyConfig(newCfg); err != nil {
+						logging.Warnf("Failed to reload config, keeping the previous one: %v", err)
+						continue
+					}
+					latestMu.Lock()
+					latest = newCfg
+					latestMu.Unlock()
+					p.Send(tuiItemsMsg(newCfg, store))
+				}
+			}()
+		}
+
 		if _, err := p.Run(); err != nil {
 			return fmt.Errorf("TUI error: %w", err)
 		}
@@ -81,6 +115,69 @@ Key bindings:
 	},
 }
 
+// loadTUIItems fetches all items, including archived ones, sorted by
+// published date (newest first) so that they are numbered like in 'list'.
+// Progress can only be shown before the TUI takes over the terminal.
+func loadTUIItems(cfg *config.Config, store *storage.Storage, progress bool) []feed.Item {
+	items, _ := fetchItems(context.Background(), cfg, store, progress)
+	allItems := withArchived(cfg, store, items)
+	sortByPublished(allItems, false)
+	return allItems
+}
+
+// tuiItemsMsg loads the items and feed errors to show in the TUI
+func tuiItemsMsg(cfg *config.Config, store *storage.Storage) tui.ItemsMsg {
+	return tui.ItemsMsg{
+		Items:   loadTUIItems(cfg, store, false),
+		Feeds:   feedNames(cfg),
+		Columns: cfg.TUIColumns,
+		Errors:  feedErrors(cfg, store),
+	}
+}
+
+// feedErrors returns the configured feeds whose last fetch failed
+func feedErrors(cfg *config.Config, store *storage.Storage) []tui.FeedError {
+	var errors []tui.FeedError
+	for _, feedCfg := range cfg.Feeds {
+		if status, ok := store.GetFeedStatus(feedCfg.URL); ok && status.LastError != "" {
+			errors = append(errors, tui.FeedError{
+				Name:  feedCfg.Name,
+				URL:   feedCfg.URL,
+				Error: status.LastError,
+			})
+		}
+	}
+	return errors
+}
+
+// retryFeed fetches a feed again for the errors view, then reloads all items
+// so that the feed's items show up if it succeeded. Other feeds are mostly
+// served from the cache.
+func retryFeed(cfg *config.Config, url string, store *storage.Storage) tea.Msg {
+	single := *cfg
+	single.Feeds = nil
+	for _, feedCfg := range cfg.Feeds {
+		if feedCfg.URL == url {
+			single.Feeds = append(single.Feeds, feedCfg)
+		}
+	}
+	fetchItems(context.Background(), &single, store, false)
+
+	return tuiItemsMsg(cfg, store)
+}
+
+// feedNames returns the names of the configured feeds, in config order, for
+// the unread counts in the TUI header. Unnamed feeds are left out.
+func feedNames(cfg *config.Config) []string {
+	var names []string
+	for _, feedCfg := range cfg.Feeds {
+		if feedCfg.Name != "" {
+			names = append(names, feedCfg.Name)
+		}
+	}
+	return names
+}
+
 func init() {
 	rootCmd.AddCommand(tuiCmd)
 }
@@ -1,52 +1,148 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"informant/internal/i18n"
+	"informant/internal/platform"
+	"informant/internal/storage"
 	"os"
+	"os/user"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	uninstallPurge bool
+	uninstallYes   bool
 )
 
 // uninstallCmd represents the uninstall command
 var uninstallCmd = &cobra.Command{
 	Use:   "uninstall",
-	Short: "Remove pacman hook from system",
-	Long: `Remove the pacman hook that was installed for system integration.
+	Short: "Remove package manager hook from system",
+	Long: `Remove the package manager hook that was installed for system integration.
+
+This will remove the pacman, apt or dnf hook installed by 'informant install'
+and disable automatic news checking during package transactions.
 
-This will remove /usr/share/libalpm/hooks/00-informant.hook and disable
-automatic news checking during pacman transactions.
+With --purge, the read status, item archive and feed cache are deleted as
+well: the system-wide storage in /var/lib and /var/cache/informant, and the
+per-user storage of root and of the user running sudo. Of a configured
+cache-dir, only informant's own cache entries are deleted. The files are
+listed and confirmation is asked first unless --yes is given.
 
-This command requires root privileges to remove the system-wide hook.`,
+This command requires root privileges to remove the system-wide hook, and
+is only supported on systems managed by pacman, apt or dnf.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if platform.PackageManager() == "" {
+			return errHookUnsupported
+		}
+
 		// Check if running with appropriate privileges
 		if os.Geteuid() != 0 {
 			return fmt.Errorf("this command requires root privileges. Please run with sudo")
 		}
 
-		hookPath := "/usr/share/libalpm/hooks/00-informant.hook"
+		// Remove the hooks of every package manager, which may have been
+		// chosen with --package-manager
+		removed := 0
+		for _, target := range hookTargets {
+			if _, err := os.Stat(target.Path); os.IsNotExist(err) {
+				continue
+			}
+			if err := os.Remove(target.Path); err != nil {
+				return fmt.Errorf("failed to remove hook file: %w", err)
+			}
+			fmt.Printf("Successfully removed %s hook from %s\n", target.Name, target.Path)
+			removed++
+		}
+
+		if removed == 0 {
+			fmt.Println("Package manager hook is not installed.")
+		} else if !uninstallPurge {
+			fmt.Println("\nPackage transactions will no longer check for news automatically.")
+			fmt.Println("You can still manually check for news using:")
+			fmt.Println("• informant check")
+			fmt.Println("• informant list")
+			fmt.Println("• informant tui")
+		}
+
+		if uninstallPurge {
+			return purgeState()
+		}
+		return nil
+	},
+}
+
+// purgeState deletes the system-wide and per-user storage after confirmation
+func purgeState() error {
+	paths := storage.StatePaths(userStorageDirs())
+	if len(paths) == 0 {
+		fmt.Println("No read status or cache files found.")
+		return nil
+	}
+
+	fmt.Println("\nThe following files will be deleted:")
+	for _, path := range paths {
+		fmt.Printf("  %s\n", path)
+	}
 
-		// Check if hook exists
-		if _, err := os.Stat(hookPath); os.IsNotExist(err) {
-			fmt.Println("Pacman hook is not installed.")
+	if !uninstallYes {
+		if isPlain() {
+			return fmt.Errorf("refusing to delete files without confirmation in plain mode, use --yes")
+		}
+		fmt.Print("Delete these files? [y/N]: ")
+		response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil || !i18n.IsYes(response) {
+			fmt.Println("Nothing was deleted.")
 			return nil
 		}
+	}
 
-		// Remove the hook file
-		if err := os.Remove(hookPath); err != nil {
-			return fmt.Errorf("failed to remove hook file: %w", err)
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
 		}
+	}
+	fmt.Printf("Deleted %d files and directories.\n", len(paths))
+
+	return nil
+}
 
-		fmt.Printf("Successfully removed pacman hook from %s\n", hookPath)
-		fmt.Println("\nPacman transactions will no longer check for Arch Linux news automatically.")
-		fmt.Println("You can still manually check for news using:")
-		fmt.Println("• informant check")
-		fmt.Println("• informant list")
-		fmt.Println("• informant tui")
+// userStorageDirs returns the directories per-user storage may have been
+// created in by root or by the user running sudo: the directory of the
+// config file in use, $XDG_CONFIG_HOME, ~/.config and the home directory
+func userStorageDirs() []string {
+	var dirs []string
+	if path := viper.ConfigFileUsed(); path != "" {
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		dirs = append(dirs, xdg)
+	}
 
-		return nil
-	},
+	var homes []string
+	if home, err := os.UserHomeDir(); err == nil {
+		homes = append(homes, home)
+	}
+	if name := os.Getenv("SUDO_USER"); name != "" {
+		if sudoUser, err := user.Lookup(name); err == nil {
+			homes = append(homes, sudoUser.HomeDir)
+		}
+	}
+	for _, home := range homes {
+		dirs = append(dirs, filepath.Join(home, ".config"), home)
+	}
+
+	return dirs
 }
 
 func init() {
 	rootCmd.AddCommand(uninstallCmd)
+
+	uninstallCmd.Flags().BoolVar(&uninstallPurge, "purge", false, "also delete the read status, item archive and feed cache")
+	uninstallCmd.Flags().BoolVarP(&uninstallYes, "yes", "y", false, "delete without asking for confirmation")
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"informant/internal/feed"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// validateFeedCmd represents the validate-feed command
+var validateFeedCmd = &cobra.Command{
+	Use:   "validate-feed <url|feed name>",
+	Short: "Check a feed for problems that hide its items",
+	Long: `Fetch a feed, given by URL or by the name of a configured feed, bypassing
+the cache, and report what informant makes of it: the detected format, how
+many entries were parsed, entries dropped because their date could not be
+parsed, entries without a GUID, character encoding problems and which content
+fields the entries use.
+
+This helps feed maintainers, and answers why a feed shows nothing. The
+command fails when problems were found. Nothing is recorded in the storage.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		name, url := resolveFeed(cfg, args[0])
+
+		body, err := feed.FetchRaw(context.Background(), url)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", url, err)
+		}
+
+		report := feed.Validate(body)
+
+		fmt.Printf("URL:     %s\n", url)
+		if name != "" {
+			fmt.Printf("Feed:    %s\n", name)
+		}
+		fmt.Printf("Format:  %s\n", report.Format)
+		if report.ParseError != nil {
+			fmt.Printf("Error:   %v\n", report.ParseError)
+		} else {
+			fmt.Printf("Items:   %d of %d entries parsed\n", report.Items, report.Entries)
+		}
+
+		if len(report.ContentFields) > 0 {
+			fields := make([]string, 0, len(report.ContentFields))
+			for field, count := range report.ContentFields {
+				fields = append(fields, fmt.Sprintf("%s (%d)", field, count))
+			}
+			sort.Strings(fields)
+			fmt.Printf("Content: %s\n", strings.Join(fields, ", "))
+		}
+
+		if !report.Problems() {
+			fmt.Println("\nNo problems found.")
+			return nil
+		}
+
+		problems := 0
+		problem := func(format string, args ...interface{}) {
+			if problems == 0 {
+				fmt.Println("\nProblems:")
+			}
+			problems++
+			fmt.Printf("- "+format+"\n", args...)
+		}
+
+		if len(report.BadDates) > 0 {
+			problem("%d entries dropped because their date is missing or could not be parsed:", len(report.BadDates))
+			for _, entry := range report.BadDates {
+				fmt.Printf("    %s\n", entry)
+			}
+		}
+		if report.MissingGUIDs > 0 {
+			problem("%d entries have no GUID, their link is used to track read status", report.MissingGUIDs)
+		}
+		if report.MissingIDs > 0 {
+			problem("%d entries have no ID or link, their read status cannot be tracked", report.MissingIDs)
+		}
+		for _, encoding := range report.Encoding {
+			problem("%s", encoding)
+		}
+		if report.DoubleEscaped > 0 {
+			problem("%d entries have titles that are HTML-escaped twice", report.DoubleEscaped)
+		}
+
+		if report.ParseError != nil {
+			return fmt.Errorf("feed could not be parsed")
+		}
+		return fmt.Errorf("%d problems found", problems)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateFeedCmd)
+}
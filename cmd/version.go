@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"informant/internal/config"
+	"informant/internal/i18n"
+	"informant/internal/logging"
+	"informant/internal/storage"
+	"informant/internal/update"
+)
+
+// versionNotice returns a one-line notice when a newer release than the
+// running version is available, or "" when there is none or "update-check"
+// is not enabled in the config
+func versionNotice(cfg *config.Config, store *storage.Storage) string {
+	if !cfg.UpdateCheck || isPlain() {
+		return ""
+	}
+
+	latest, err := update.Latest(context.Background(), store)
+	if err != nil {
+		logging.Infof("Failed to check for a new version: %v", err)
+		return ""
+	}
+	if latest == "" || !update.Newer(latest, version) {
+		return ""
+	}
+	return i18n.T("informant %s is available (you have %s)", latest, version)
+}
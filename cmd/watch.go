@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"informant/internal/daemon"
+	"informant/internal/feed"
+	"informant/internal/logging"
+	"informant/internal/metrics"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// watchFetchTimeout bounds a refresh, which keeps the pacman hook waiting
+// while it holds the fetch lock
+const watchFetchTimeout = time.Minute
+
+var (
+	watchInterval    time.Duration
+	watchMetricsFile string
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Periodically check feeds in the background",
+	Long: `Run as a daemon that periodically refreshes all feeds and sends
+notifications when new unread items appear.
+
+While watch is running, other informant invocations such as 'list' and 'count'
+ask it for the latest items over a unix socket instead of fetching the feeds
+again.
+
+Changes to the config file, such as added feeds, are picked up without a
+restart and trigger an immediate refresh.
+
+With --metrics-file, Prometheus metrics are written to the file after every
+refresh, for node_exporter's textfile collector.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		store, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		d := &daemon.Daemon{}
+
+		socketPath := daemon.SocketPath()
+		listener, err := daemon.Listen(socketPath)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(socketPath)
+		defer listener.Close()
+
+		go d.Serve(listener)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		refresh := func() {
+			unlock := lockFetch(ctx, store)
+			defer unlock()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, watchFetchTimeout)
+			defer cancel()
+
+			items, _ := fetchItems(fetchCtx, cfg, store, false)
+			urls := make([]string, len(cfg.Feeds))
+			for i, feedCfg := range cfg.Feeds {
+				urls[i] = feedCfg.URL
+			}
+			d.Update(urls, items)
+
+			var unreadItems []feed.Item
+			for _, item := range items {
+				if isPending(store, item) {
+					unreadItems = append(unreadItems, item)
+				}
+			}
+
+			logging.Event(logging.LevelInfo, "Refreshed feeds", logging.Fields{
+				"items":  len(items),
+				"unread": len(unreadItems),
+			})
+
+			notifyNewItems(cfg, store, unreadItems)
+
+			if watchMetricsFile != "" {
+				if err := metrics.WriteFile(watchMetricsFile, feedMetrics(cfg, store, items)); err != nil {
+					logging.Warnf("Failed to write metrics: %v", err)
+				}
+			}
+		}
+
+		refresh()
+
+		configChanged := watchConfigChanges()
+
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				refresh()
+			case newCfg := <-configChanged:
+				// Keep running with the previous config if the new one is broken
+				if err := applyConfig(newCfg); err != nil {
+					logging.Warnf("Failed to reload config, keeping the previous one: %v", err)
+					continue
+				}
+				cfg = newCfg
+				refresh()
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Minute, "time between feed refreshes")
+	watchCmd.Flags().StringVar(&watchMetricsFile, "metrics-file", "", "write Prometheus metrics to this file after every refresh, e.g. /var/lib/node_exporter/textfile_collector/informant.prom")
+}
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -15,16 +19,199 @@ type Feed struct {
 	TitleKey     string `json:"title-key,omitempty" mapstructure:"title-key"`
 	BodyKey      string `json:"body-key,omitempty" mapstructure:"body-key"`
 	TimestampKey string `json:"timestamp-key,omitempty" mapstructure:"timestamp-key"`
+	// Tags are added to every item of the feed
+	Tags []string `json:"tags,omitempty" mapstructure:"tags"`
+
+	// TLS settings for feeds served with private CAs or mutual TLS
+	CACert             string `json:"ca-cert,omitempty" mapstructure:"ca-cert"`
+	ClientCert         string `json:"client-cert,omitempty" mapstructure:"client-cert"`
+	ClientKey          string `json:"client-key,omitempty" mapstructure:"client-key"`
+	InsecureSkipVerify bool   `json:"insecure-skip-verify,omitempty" mapstructure:"insecure-skip-verify"`
+}
+
+// HasTLSOptions reports whether the feed customizes TLS
+func (f Feed) HasTLSOptions() bool {
+	return f.CACert != "" || f.ClientCert != "" || f.ClientKey != "" || f.InsecureSkipVerify
+}
+
+// Plugin types
+const (
+	// PluginFetcher fetches feeds with URLs of its scheme
+	PluginFetcher = "fetcher"
+	// PluginProcessor post-processes the items of every feed
+	PluginProcessor = "processor"
+)
+
+// Plugin is an external-process plugin
+type Plugin struct {
+	Name    string `json:"name" mapstructure:"name"`
+	Type    string `json:"type" mapstructure:"type"`
+	Command string `json:"command" mapstructure:"command"`
+	// Scheme is the URL scheme a fetcher handles, such as "gemini"
+	Scheme string `json:"scheme,omitempty" mapstructure:"scheme"`
+}
+
+// Notification represents a push notification endpoint
+type Notification struct {
+	Type     string `json:"type" mapstructure:"type"`
+	URL      string `json:"url" mapstructure:"url"`
+	Token    string `json:"token,omitempty" mapstructure:"token"`
+	Priority int    `json:"priority,omitempty" mapstructure:"priority"`
+
+	// Webhook-only settings
+	Method   string            `json:"method,omitempty" mapstructure:"method"`
+	Headers  map[string]string `json:"headers,omitempty" mapstructure:"headers"`
+	Template string            `json:"template,omitempty" mapstructure:"template"`
 }
 
+// Sync represents a remote endpoint the read status is synced with
+type Sync struct {
+	Type string `json:"type" mapstructure:"type"`
+	URL  string `json:"url" mapstructure:"url"`
+	Auto bool   `json:"auto,omitempty" mapstructure:"auto"`
+
+	// WebDAV credentials
+	Username string `json:"username,omitempty" mapstructure:"username"`
+	Password string `json:"password,omitempty" mapstructure:"password"`
+
+	// S3-compatible object location and credentials
+	Bucket    string `json:"bucket,omitempty" mapstructure:"bucket"`
+	Key       string `json:"key,omitempty" mapstructure:"key"`
+	Region    string `json:"region,omitempty" mapstructure:"region"`
+	AccessKey string `json:"access-key,omitempty" mapstructure:"access-key"`
+	SecretKey string `json:"secret-key,omitempty" mapstructure:"secret-key"`
+}
+
+// Rule matches items by a regular expression against their title and/or content
+type Rule struct {
+	Pattern string `json:"pattern" mapstructure:"pattern"`
+	Field   string `json:"field,omitempty" mapstructure:"field"`
+	Feed    string `json:"feed,omitempty" mapstructure:"feed"`
+}
+
+// SeverityRule assigns a severity level to items matching a rule
+type SeverityRule struct {
+	Level string `json:"level" mapstructure:"level"`
+	Rule  `mapstructure:",squash"`
+}
+
+// Severity levels of news items, from least to most severe
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// severityLevels are ordered from least to most severe
+var severityLevels = []string{SeverityInfo, SeverityWarning, SeverityCritical}
+
+// SeverityRank orders severity levels, returning -1 for unknown ones
+func SeverityRank(level string) int {
+	for i, name := range severityLevels {
+		if name == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// DefaultSeverityRules classify items when no severity rules are configured:
+// Arch Linux announces breaking changes as requiring manual intervention
+var DefaultSeverityRules = []SeverityRule{
+	{Level: SeverityCritical, Rule: Rule{Pattern: `(?i)manual intervention`}},
+}
+
+// DefaultMaxFeedSize is the largest feed response accepted when no limit is configured
+const DefaultMaxFeedSize = 4 << 20
+
+// Cache lifetimes of fetched feeds when none are configured: the lifetime
+// used when the server gives none, and the bounds of those it gives
+const (
+	DefaultCacheTTL    = 15 * time.Minute
+	DefaultCacheMinTTL = time.Minute
+	DefaultCacheMaxTTL = time.Hour
+)
+
+// DefaultHostMaxConcurrency caps concurrent requests to one host when no
+// limit is configured
+const DefaultHostMaxConcurrency = 2
+
+// DefaultCheckTimeout bounds how long check waits for feeds when no timeout is configured
+const DefaultCheckTimeout = 20 * time.Second
+
+// Policies for check when feeds cannot be fetched
+const (
+	// FailOpen continues with the feeds that could be fetched
+	FailOpen = "open"
+	// FailClosed makes check fail when any feed could not be fetched
+	FailClosed = "closed"
+)
+
+// TUIColumns lists the columns that can be shown in the TUI item list
+var TUIColumns = []string{"index", "status", "star", "date", "feed", "title", "tags", "author"}
+
+// Formats of item content in the TUI reader
+const (
+	// ReaderFormatMarkdown renders the item HTML as styled Markdown
+	ReaderFormatMarkdown = "markdown"
+	// ReaderFormatPlain shows the text with all markup stripped
+	ReaderFormatPlain = "plain"
+)
+
+// MinTUIRefreshInterval keeps TUI auto-refresh from hammering feed servers
+const MinTUIRefreshInterval = time.Minute
+
+// DefaultTUIColumns are the TUI list columns used when none are configured
+var DefaultTUIColumns = []string{"index", "status", "star", "date", "title", "feed"}
+
 // Config represents the application configuration
 type Config struct {
-	Feeds []Feed `json:"feeds" mapstructure:"feeds"`
+	Feeds         []Feed              `json:"feeds" mapstructure:"feeds"`
+	Profiles      map[string][]string `json:"profiles,omitempty" mapstructure:"profiles"`
+	MaxFeedSize   int64               `json:"max-feed-size,omitempty" mapstructure:"max-feed-size"`
+	Notifications []Notification      `json:"notifications,omitempty" mapstructure:"notifications"`
+	Plugins       []Plugin            `json:"plugins,omitempty" mapstructure:"plugins"`
+
+	CacheTTL    time.Duration `json:"cache-ttl,omitempty" mapstructure:"cache-ttl"`
+	CacheMinTTL time.Duration `json:"cache-min-ttl,omitempty" mapstructure:"cache-min-ttl"`
+	CacheMaxTTL time.Duration `json:"cache-max-ttl,omitempty" mapstructure:"cache-max-ttl"`
+
+	HostMaxConcurrency int           `json:"host-max-concurrency,omitempty" mapstructure:"host-max-concurrency"`
+	HostMinInterval    time.Duration `json:"host-min-interval,omitempty" mapstructure:"host-min-interval"`
+
+	ReadStatusLayout string `json:"read-status-layout,omitempty" mapstructure:"read-status-layout"`
+	StorageBackend   string `json:"storage-backend,omitempty" mapstructure:"storage-backend"`
+	Sync             *Sync  `json:"sync,omitempty" mapstructure:"sync"`
+
+	Ignore               []Rule `json:"ignore,omitempty" mapstructure:"ignore"`
+	Highlight            []Rule `json:"highlight,omitempty" mapstructure:"highlight"`
+	CheckHighlightedOnly bool   `json:"check-highlighted-only,omitempty" mapstructure:"check-highlighted-only"`
+
+	Severity         []SeverityRule `json:"severity,omitempty" mapstructure:"severity"`
+	CheckMinSeverity string         `json:"check-min-severity,omitempty" mapstructure:"check-min-severity"`
+
+	CheckTimeout       time.Duration `json:"check-timeout,omitempty" mapstructure:"check-timeout"`
+	CheckFailurePolicy string        `json:"check-failure-policy,omitempty" mapstructure:"check-failure-policy"`
+	CheckFailOnUnread  bool          `json:"check-fail-on-unread,omitempty" mapstructure:"check-fail-on-unread"`
+
+	TUIColumns         []string      `json:"tui-columns,omitempty" mapstructure:"tui-columns"`
+	TUIRefreshInterval time.Duration `json:"tui-refresh-interval,omitempty" mapstructure:"tui-refresh-interval"`
+	ReaderFormat       string        `json:"reader-format,omitempty" mapstructure:"reader-format"`
+
+	UpdateCheck bool `json:"update-check,omitempty" mapstructure:"update-check"`
+
+	// InitialMarkRead marks the items already in the feeds as read when the
+	// read status is first created
+	InitialMarkRead bool `json:"initial-mark-read,omitempty" mapstructure:"initial-mark-read"`
+
+	// AllFeeds are the configured feeds before selecting those of a profile
+	AllFeeds []Feed `json:"-" mapstructure:"-"`
 }
 
-// SetDefaults sets default configuration values
-func SetDefaults() {
-	viper.SetDefault("feeds", []map[string]interface{}{
+// SetDefaults sets default configuration values on v
+func SetDefaults(v *viper.Viper) {
+	// Keep in sync with DefaultFeed
+	v.SetDefault("feeds", []map[string]interface{}{
 		{
 			"name":          "Arch Linux News",
 			"url":           "https://archlinux.org/feeds/news/",
@@ -35,11 +222,73 @@ func SetDefaults() {
 	})
 }
 
-// Load loads the configuration from viper
+// EnvPrefix prefixes the environment variables that override settings, such
+// as INFORMANT_CACHE_TTL for cache-ttl
+const EnvPrefix = "INFORMANT"
+
+// envKeyReplacer turns setting keys into environment variable names
+var envKeyReplacer = strings.NewReplacer("-", "_", ".", "_")
+
+// EnvName returns the environment variable that overrides a setting
+func EnvName(key string) string {
+	return EnvPrefix + "_" + envKeyReplacer.Replace(strings.ToUpper(key))
+}
+
+// BindEnv lets INFORMANT_ environment variables override settings. viper
+// only looks up the environment for keys it already knows when unmarshaling,
+// so the settings of Config are bound explicitly. Feeds and the other lists
+// of objects can only be set in config files.
+func BindEnv(v *viper.Viper) {
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+	bindEnv(v, "", reflect.TypeOf(Config{}))
+}
+
+// bindEnv binds the settings of a config struct, nested ones under prefix
+func bindEnv(v *viper.Viper, prefix string, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			bindEnv(v, key+".", fieldType)
+		case reflect.Map:
+		case reflect.Slice:
+			// Lists of strings are given comma-separated
+			if fieldType.Elem().Kind() == reflect.String {
+				v.BindEnv(key)
+			}
+		default:
+			v.BindEnv(key)
+		}
+	}
+}
+
+// DefaultFeed is the feed used when none are configured
+var DefaultFeed = Feed{
+	Name:         "Arch Linux News",
+	URL:          "https://archlinux.org/feeds/news/",
+	TitleKey:     "title",
+	BodyKey:      "summary",
+	TimestampKey: "published",
+}
+
+// Load loads the configuration from the global viper
 func Load() (*Config, error) {
+	return LoadFrom(viper.GetViper())
+}
+
+// LoadFrom loads the configuration from v
+func LoadFrom(v *viper.Viper) (*Config, error) {
 	var cfg Config
 
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
@@ -56,16 +305,309 @@ func Load() (*Config, error) {
 		}
 	}
 
+	if cfg.MaxFeedSize <= 0 {
+		cfg.MaxFeedSize = DefaultMaxFeedSize
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = DefaultCacheTTL
+	}
+	if cfg.CacheMinTTL <= 0 {
+		cfg.CacheMinTTL = DefaultCacheMinTTL
+	}
+	if cfg.CacheMaxTTL <= 0 {
+		cfg.CacheMaxTTL = DefaultCacheMaxTTL
+	}
+	if cfg.HostMaxConcurrency == 0 {
+		cfg.HostMaxConcurrency = DefaultHostMaxConcurrency
+	}
+	if cfg.CheckTimeout <= 0 {
+		cfg.CheckTimeout = DefaultCheckTimeout
+	}
+	if cfg.CheckFailurePolicy == "" {
+		cfg.CheckFailurePolicy = FailOpen
+	}
+	if len(cfg.TUIColumns) == 0 {
+		cfg.TUIColumns = DefaultTUIColumns
+	}
+	if cfg.ReaderFormat == "" {
+		cfg.ReaderFormat = ReaderFormatMarkdown
+	}
+	if cfg.Severity == nil {
+		cfg.Severity = DefaultSeverityRules
+	}
+
 	// Validate configuration
 	for _, feed := range cfg.Feeds {
 		if feed.URL == "" {
 			return nil, fmt.Errorf("feed URL cannot be empty")
 		}
+		if strings.TrimSpace(strings.TrimPrefix(feed.URL, "exec:")) == "" {
+			return nil, fmt.Errorf("feed %q: exec feed needs a command", feed.URL)
+		}
+		if (feed.ClientCert == "") != (feed.ClientKey == "") {
+			return nil, fmt.Errorf("feed %q: client-cert and client-key must be set together", feed.URL)
+		}
+	}
+
+	if cfg.CacheMinTTL > cfg.CacheMaxTTL {
+		return nil, fmt.Errorf("cache-min-ttl (%s) cannot be longer than cache-max-ttl (%s)", cfg.CacheMinTTL, cfg.CacheMaxTTL)
+	}
+
+	if cfg.HostMinInterval < 0 {
+		return nil, fmt.Errorf("host-min-interval cannot be negative")
+	}
+
+	if cfg.CheckFailurePolicy != FailOpen && cfg.CheckFailurePolicy != FailClosed {
+		return nil, fmt.Errorf("unknown check-failure-policy: %q", cfg.CheckFailurePolicy)
+	}
+
+	switch cfg.ReadStatusLayout {
+	case "", ReadStatusLayoutFile, ReadStatusLayoutPerItem:
+	default:
+		return nil, fmt.Errorf("unknown read-status-layout: %q", cfg.ReadStatusLayout)
+	}
+
+	switch cfg.StorageBackend {
+	case "", StorageBackendFile:
+	case StorageBackendBolt:
+		if cfg.ReadStatusLayout == ReadStatusLayoutPerItem {
+			return nil, fmt.Errorf("read-status-layout %q cannot be used with storage-backend %q", ReadStatusLayoutPerItem, StorageBackendBolt)
+		}
+	default:
+		return nil, fmt.Errorf("unknown storage-backend: %q", cfg.StorageBackend)
+	}
+
+	if cfg.ReaderFormat != ReaderFormatMarkdown && cfg.ReaderFormat != ReaderFormatPlain {
+		return nil, fmt.Errorf("unknown reader-format: %q", cfg.ReaderFormat)
+	}
+
+	for _, column := range cfg.TUIColumns {
+		if !isTUIColumn(column) {
+			return nil, fmt.Errorf("unknown tui column: %q", column)
+		}
+	}
+	if cfg.TUIRefreshInterval != 0 && cfg.TUIRefreshInterval < MinTUIRefreshInterval {
+		return nil, fmt.Errorf("tui-refresh-interval must be at least %s", MinTUIRefreshInterval)
+	}
+
+	for _, rule := range cfg.Ignore {
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid ignore rule: %w", err)
+		}
+	}
+
+	for _, rule := range cfg.Highlight {
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid highlight rule: %w", err)
+		}
+	}
+
+	for _, rule := range cfg.Severity {
+		if SeverityRank(rule.Level) < 0 {
+			return nil, fmt.Errorf("invalid severity rule: unknown level %q", rule.Level)
+		}
+		if err := validateRule(rule.Rule); err != nil {
+			return nil, fmt.Errorf("invalid severity rule: %w", err)
+		}
+	}
+
+	if cfg.CheckMinSeverity != "" && SeverityRank(cfg.CheckMinSeverity) < 0 {
+		return nil, fmt.Errorf("unknown check-min-severity: %q", cfg.CheckMinSeverity)
+	}
+
+	if cfg.Sync != nil {
+		if err := validateSync(cfg.Sync); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.AllFeeds = cfg.Feeds
+	if profile := v.GetString("profile"); profile != "" {
+		feeds, err := profileFeeds(cfg.Feeds, cfg.Profiles, profile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Feeds = feeds
+	}
+
+	for _, plugin := range cfg.Plugins {
+		if strings.TrimSpace(plugin.Command) == "" {
+			return nil, fmt.Errorf("plugin %q: command cannot be empty", plugin.Name)
+		}
+		switch plugin.Type {
+		case PluginFetcher:
+			if plugin.Scheme == "" {
+				return nil, fmt.Errorf("plugin %q: fetcher plugins need a scheme", plugin.Name)
+			}
+		case PluginProcessor:
+			if plugin.Name == "" {
+				return nil, fmt.Errorf("processor plugins need a name")
+			}
+		default:
+			return nil, fmt.Errorf("plugin %q: unknown type %q", plugin.Name, plugin.Type)
+		}
+	}
+
+	for _, n := range cfg.Notifications {
+		switch n.Type {
+		case "ntfy", "gotify", "webhook":
+		default:
+			return nil, fmt.Errorf("unknown notification type: %q", n.Type)
+		}
+		if n.URL == "" {
+			return nil, fmt.Errorf("notification URL cannot be empty")
+		}
 	}
 
 	return &cfg, nil
 }
 
+// ProfileFeeds returns the feeds of a profile of the config
+func (c *Config) ProfileFeeds(profile string) ([]Feed, error) {
+	return profileFeeds(c.AllFeeds, c.Profiles, profile)
+}
+
+// profileFeeds returns the feeds of a profile, which lists them by name or
+// URL. Profile names are case-insensitive, as viper lowercases map keys.
+func profileFeeds(feeds []Feed, profiles map[string][]string, profile string) ([]Feed, error) {
+	refs, ok := profiles[strings.ToLower(profile)]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile: %q", profile)
+	}
+
+	var selected []Feed
+	for _, ref := range refs {
+		found := false
+		for _, feed := range feeds {
+			if feed.Name == ref || feed.URL == ref {
+				selected = append(selected, feed)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("profile %q refers to unknown feed %q", profile, ref)
+		}
+	}
+	return selected, nil
+}
+
+// isTUIColumn reports whether name is a known TUI list column
+func isTUIColumn(name string) bool {
+	for _, column := range TUIColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRule checks that a rule has a valid pattern and field
+func validateRule(rule Rule) error {
+	if _, err := regexp.Compile(rule.Pattern); err != nil {
+		return fmt.Errorf("bad pattern %q: %w", rule.Pattern, err)
+	}
+
+	switch rule.Field {
+	case "", "title", "content":
+	default:
+		return fmt.Errorf("unknown field %q", rule.Field)
+	}
+
+	return nil
+}
+
+// validateSync checks that a sync configuration is complete
+func validateSync(sync *Sync) error {
+	switch sync.Type {
+	case "webdav":
+	case "s3":
+		if sync.Bucket == "" || sync.Key == "" {
+			return fmt.Errorf("s3 sync requires bucket and key")
+		}
+	default:
+		return fmt.Errorf("unknown sync type: %q", sync.Type)
+	}
+	if sync.URL == "" {
+		return fmt.Errorf("sync URL cannot be empty")
+	}
+	return nil
+}
+
+// GetSync returns the remote sync configuration, or nil when sync is not configured
+func GetSync() (*Sync, error) {
+	if !viper.IsSet("sync") {
+		return nil, nil
+	}
+
+	var sync Sync
+	if err := viper.UnmarshalKey("sync", &sync); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync config: %w", err)
+	}
+	if err := validateSync(&sync); err != nil {
+		return nil, err
+	}
+
+	return &sync, nil
+}
+
+// Read status layouts supported by storage
+const (
+	// ReadStatusLayoutFile keeps all read marks in a single JSON file
+	ReadStatusLayoutFile = "file"
+	// ReadStatusLayoutPerItem keeps one file per item so that file syncing
+	// tools can merge marks made on different machines
+	ReadStatusLayoutPerItem = "per-item"
+)
+
+// Storage backends
+const (
+	// StorageBackendFile keeps read status, cache and archive in JSON files
+	StorageBackendFile = "file"
+	// StorageBackendBolt keeps them in a single bbolt database file
+	StorageBackendBolt = "bolt"
+)
+
+// GetStorageBackend returns the configured storage backend
+func GetStorageBackend() string {
+	if viper.GetString("storage-backend") == StorageBackendBolt {
+		return StorageBackendBolt
+	}
+	return StorageBackendFile
+}
+
+// GetStoragePath returns the configured read status file, which replaces
+// both the system-wide and the per-user location when set
+func GetStoragePath() string {
+	return viper.GetString("storage-path")
+}
+
+// GetCacheDir returns the configured feed cache directory, if any
+func GetCacheDir() string {
+	return viper.GetString("cache-dir")
+}
+
+// DefaultStorageGroup is the group sharing the system-wide storage when none
+// is configured
+const DefaultStorageGroup = "informant"
+
+// GetStorageGroup returns the group whose members may update the
+// system-wide storage
+func GetStorageGroup() string {
+	if group := viper.GetString("storage-group"); group != "" {
+		return group
+	}
+	return DefaultStorageGroup
+}
+
+// GetReadStatusLayout returns the configured read status storage layout
+func GetReadStatusLayout() string {
+	if viper.GetString("read-status-layout") == ReadStatusLayoutPerItem {
+		return ReadStatusLayoutPerItem
+	}
+	return ReadStatusLayoutFile
+}
+
 // GetConfigPath returns the path where the read status file should be stored
 func GetConfigPath() (string, error) {
 	// Try to use the same directory as the config file
@@ -79,17 +621,41 @@ func GetConfigPath() (string, error) {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	// Try XDG_CONFIG_HOME first
-	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
-	if xdgConfig != "" {
-		return xdgConfig, nil
+	// The platform's config directory: $XDG_CONFIG_HOME or ~/.config on
+	// Linux, ~/Library/Application Support on macOS
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return home, nil
+	}
+	if os.Getenv("XDG_CONFIG_HOME") != "" {
+		return configDir, nil
 	}
 
-	// Default to ~/.config or home directory
-	configDir := filepath.Join(home, ".config")
+	// Use the home directory when the config directory was never created
 	if _, err := os.Stat(configDir); os.IsNotExist(err) {
 		return home, nil
 	}
 
 	return configDir, nil
 }
+
+// TimezoneLocal shows timestamps in the system's local timezone
+const TimezoneLocal = "local"
+
+// GetTimezone returns the timezone timestamps are shown in: nil to keep them
+// as the feed gives them, the local zone, or a named IANA zone
+func GetTimezone() (*time.Location, error) {
+	name := viper.GetString("timezone")
+	switch {
+	case name == "":
+		return nil, nil
+	case strings.EqualFold(name, TimezoneLocal):
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseNewsboatURLs converts a newsboat urls file into feed configs. Each
+// line holds a URL followed by tags, which may be quoted; a "~Name" tag
+// overrides the feed name and the "!" tag that hides a feed in newsboat is
+// dropped. Query and filter feeds have no informant equivalent and are
+// returned as skipped.
+func ParseNewsboatURLs(r io.Reader) (feeds []Feed, skipped []string, err error) {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields, err := splitNewsboatLine(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		url := fields[0]
+		if strings.HasPrefix(url, "query:") || strings.HasPrefix(url, "filter:") {
+			skipped = append(skipped, url)
+			continue
+		}
+
+		feed := Feed{URL: url}
+		for _, tag := range fields[1:] {
+			switch {
+			case strings.HasPrefix(tag, "~"):
+				feed.Name = strings.TrimPrefix(tag, "~")
+			case tag == "!" || tag == "":
+			default:
+				feed.Tags = append(feed.Tags, tag)
+			}
+		}
+		feeds = append(feeds, feed)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read urls file: %w", err)
+	}
+
+	return feeds, skipped, nil
+}
+
+// splitNewsboatLine splits a urls file line on whitespace, keeping quoted
+// parts such as "~Arch Linux News" together
+func splitNewsboatLine(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inQuotes, inField := false, false
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inField = true
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case !inQuotes && r == '#' && !inField:
+			// The rest of the line is a comment
+			return fields, nil
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+
+	return fields, nil
+}
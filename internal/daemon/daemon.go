@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"informant/internal/feed"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// queryTimeout bounds how long a client waits for the daemon to answer
+const queryTimeout = 2 * time.Second
+
+// State is the in-memory state a running daemon shares with other invocations
+type State struct {
+	// Feeds are the URLs of the feeds the daemon fetches
+	Feeds       []string    `json:"feeds"`
+	Items       []feed.Item `json:"items"`
+	RefreshedAt time.Time   `json:"refreshed_at"`
+}
+
+// Daemon holds the latest fetched items and answers status queries on a
+// unix socket. The protocol is line based: a client sends "status\n" and
+// receives the State as a single JSON document.
+type Daemon struct {
+	mutex sync.RWMutex
+	state State
+}
+
+// SocketPath returns the per-user unix socket path used by the daemon
+func SocketPath() string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "informant.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("informant-%d.sock", os.Getuid()))
+}
+
+// Update replaces the daemon's state with the items freshly fetched from
+// the feeds with the given URLs
+func (d *Daemon) Update(feeds []string, items []feed.Item) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.state = State{
+		Feeds:       feeds,
+		Items:       items,
+		RefreshedAt: time.Now(),
+	}
+}
+
+// State returns a copy of the daemon's current state
+func (d *Daemon) State() State {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	state := d.state
+	state.Feeds = append([]string(nil), d.state.Feeds...)
+	state.Items = append([]feed.Item(nil), d.state.Items...)
+	return state
+}
+
+// Listen opens the daemon socket, replacing a stale one from a previous run.
+// It refuses to replace the socket of a daemon that is still running.
+func Listen(path string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", path, queryTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("another daemon is already listening on %s", path)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+// Serve answers status queries until the listener is closed
+func (d *Daemon) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go d.handle(conn)
+	}
+}
+
+// handle answers a single client connection
+func (d *Daemon) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(queryTimeout))
+
+	request, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	switch strings.TrimSpace(request) {
+	case "status":
+		json.NewEncoder(conn).Encode(d.State())
+	default:
+		fmt.Fprintf(conn, "{\"error\":%q}\n", "unknown request")
+	}
+}
+
+// Query asks a running daemon for its state. It returns an error when no
+// daemon is listening on the socket.
+func Query(path string) (*State, error) {
+	conn, err := net.DialTimeout("unix", path, queryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("daemon not reachable: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(queryTimeout))
+
+	if _, err := fmt.Fprintln(conn, "status"); err != nil {
+		return nil, fmt.Errorf("failed to query daemon: %w", err)
+	}
+
+	var state State
+	if err := json.NewDecoder(conn).Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to read daemon state: %w", err)
+	}
+
+	return &state, nil
+}
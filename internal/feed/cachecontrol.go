@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cache lifetimes of fetched feeds, set from the config. CacheTTL is used
+// when the server does not say how long a response stays fresh; lifetimes
+// from Cache-Control or Expires headers are bounded by CacheMinTTL and
+// CacheMaxTTL.
+var (
+	CacheTTL    = 15 * time.Minute
+	CacheMinTTL = time.Minute
+	CacheMaxTTL = time.Hour
+)
+
+// ExpiringCacheStorage is a CacheStorage that can keep data until the
+// expiry the server gave instead of for a fixed age
+type ExpiringCacheStorage interface {
+	CacheStorage
+	SetCacheFileUntil(url string, data []byte, expires time.Time) error
+}
+
+// cacheHint is how long a response may be cached, as its headers say
+type cacheHint struct {
+	ttl time.Duration
+	ok  bool
+}
+
+type cacheHintKey struct{}
+
+// withCacheHint returns a context under which fetchHTTP records the cache
+// lifetime of the response into hint
+func withCacheHint(ctx context.Context, hint *cacheHint) context.Context {
+	return context.WithValue(ctx, cacheHintKey{}, hint)
+}
+
+// cacheHintFrom returns the hint to record into, nil if none was asked for
+func cacheHintFrom(ctx context.Context) *cacheHint {
+	hint, _ := ctx.Value(cacheHintKey{}).(*cacheHint)
+	return hint
+}
+
+// bounded returns the lifetime to cache a response for, and false when the
+// server gave none and CacheTTL applies
+func (h *cacheHint) bounded() (time.Duration, bool) {
+	if !h.ok {
+		return 0, false
+	}
+	switch {
+	case h.ttl < CacheMinTTL:
+		return CacheMinTTL, true
+	case h.ttl > CacheMaxTTL:
+		return CacheMaxTTL, true
+	}
+	return h.ttl, true
+}
+
+// freshness returns how long a response stays fresh according to its
+// Cache-Control and Expires headers, and false when they do not say.
+// Cache-Control takes precedence, as HTTP caching requires.
+func freshness(header http.Header, now time.Time) (time.Duration, bool) {
+	age := time.Duration(0)
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header.Get("Age"))); err == nil && seconds > 0 {
+		age = time.Duration(seconds) * time.Second
+	}
+
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return 0, true
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil {
+				continue
+			}
+			return time.Duration(seconds)*time.Second - age, true
+		}
+	}
+
+	expiresHeader := header.Get("Expires")
+	if expiresHeader == "" {
+		return 0, false
+	}
+	// Invalid dates, such as "0", mean already expired
+	expires, err := http.ParseTime(expiresHeader)
+	if err != nil {
+		return 0, true
+	}
+	// Measure against the server's clock when it gives one
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		now = date
+	}
+	return expires.Sub(now) - age, true
+}
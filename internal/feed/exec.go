@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ExecScheme prefixes feed URLs that run a command and read the feed from
+// its stdout, such as "exec:/usr/local/bin/my-feed-script --list"
+const ExecScheme = "exec:"
+
+// execCommand splits a command feed URL into the program and its arguments.
+// Both "exec:/path" and "exec:///path" are accepted.
+func execCommand(url string) []string {
+	command := strings.TrimPrefix(url, ExecScheme)
+	if strings.HasPrefix(command, "///") {
+		command = strings.TrimPrefix(command, "//")
+	}
+	return strings.Fields(command)
+}
+
+// fetchExec runs a command feed and returns its stdout
+func fetchExec(ctx context.Context, url string) ([]byte, error) {
+	args := execCommand(url)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command given in %q", url)
+	}
+	return runCommand(ctx, args, nil)
+}
+
+// runCommand runs a command with the given stdin and returns its stdout,
+// refusing output larger than MaxResponseSize
+func runCommand(ctx context.Context, args []string, stdin io.Reader) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+
+	// Read one byte past the limit so oversized output is detected
+	body, readErr := io.ReadAll(io.LimitReader(stdout, MaxResponseSize+1))
+	if int64(len(body)) > MaxResponseSize {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, fmt.Errorf("output of %s exceeds limit of %d bytes", args[0], MaxResponseSize)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %w", args[0], err)
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read output of %s: %w", args[0], readErr)
+	}
+
+	return body, nil
+}
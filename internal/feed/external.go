@@ -0,0 +1,62 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ExternalFetcher is a fetcher plugin run as a separate process. The command
+// is called with the feed URL as its last argument and writes the feed data
+// to stdout.
+type ExternalFetcher struct {
+	Command string
+}
+
+// Fetch runs the plugin command for url
+func (f ExternalFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	args := strings.Fields(f.Command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("fetcher plugin has no command")
+	}
+	return runCommand(ctx, append(args, url), nil)
+}
+
+// ExternalProcessor is a processor plugin run as a separate process. The
+// command reads the items as a JSON array on stdin and writes the processed
+// items, in the same form, to stdout.
+type ExternalProcessor struct {
+	ProcessorName string
+	Command       string
+}
+
+// Name returns the configured plugin name
+func (p ExternalProcessor) Name() string {
+	return p.ProcessorName
+}
+
+// Process pipes items through the plugin command
+func (p ExternalProcessor) Process(ctx context.Context, items []Item) ([]Item, error) {
+	args := strings.Fields(p.Command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("processor plugin has no command")
+	}
+
+	input, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode items: %w", err)
+	}
+
+	output, err := runCommand(ctx, args, bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var processed []Item
+	if err := json.Unmarshal(output, &processed); err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
+	return processed, nil
+}
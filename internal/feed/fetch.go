@@ -0,0 +1,156 @@
+package feed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// MaxResponseSize is the largest feed body, in bytes, that will be downloaded
+var MaxResponseSize int64 = 4 << 20
+
+// gzipMagic is the header every gzip stream starts with
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// transport is shared by all feed fetches so that feeds hosted on the same
+// server reuse keep-alive connections instead of repeating TLS handshakes
+var transport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          16,
+	MaxIdleConnsPerHost:   4,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ResponseHeaderTimeout: 15 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// httpClient is safe for concurrent use by multiple goroutines
+var httpClient = &http.Client{
+	Transport: transport,
+	Timeout:   30 * time.Second,
+}
+
+// fetch retrieves the feed body with the fetcher registered for the URL's
+// scheme. Compressed bodies are returned as-is so they can be cached in that
+// form.
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	if replayDir != "" {
+		return replay(url)
+	}
+
+	fetcher, err := fetcherFor(url)
+	if err != nil {
+		return nil, err
+	}
+	body, err := fetcher.Fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if recordDir != "" {
+		if err := record(url, body); err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
+// fetchHTTP downloads the feed body from the network, refusing responses
+// larger than MaxResponseSize. Requests to the same host are limited by
+// HostMaxConcurrency and HostMinInterval.
+func fetchHTTP(ctx context.Context, url string) ([]byte, error) {
+	if timing := timingFrom(ctx); timing != nil {
+		ctx = traceTiming(ctx, timing)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Setting Accept-Encoding explicitly disables the transport's transparent
+	// decompression; decompress handles the body instead
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	release, err := waitForHost(ctx, req.URL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer release()
+
+	resp, err := clientFor(url).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	if hint := cacheHintFrom(ctx); hint != nil {
+		hint.ttl, hint.ok = freshness(resp.Header, time.Now())
+	}
+
+	if resp.ContentLength > MaxResponseSize {
+		return nil, fmt.Errorf("feed too large: %d bytes exceeds limit of %d bytes", resp.ContentLength, MaxResponseSize)
+	}
+
+	// Read one byte past the limit so oversized bodies without a
+	// Content-Length header are still detected
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+	if int64(len(body)) > MaxResponseSize {
+		return nil, fmt.Errorf("feed too large: exceeds limit of %d bytes", MaxResponseSize)
+	}
+
+	return body, nil
+}
+
+// FetchRaw downloads a feed bypassing the cache and returns its
+// uncompressed body, for debugging feeds
+func FetchRaw(ctx context.Context, url string) ([]byte, error) {
+	body, err := fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(body)
+}
+
+// decompress inflates gzip-compressed feed data. The gzip magic bytes are
+// checked rather than Content-Encoding so that bodies compressed by proxies,
+// and compressed payloads read back from the cache, are handled alike.
+func decompress(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress feed: %w", err)
+	}
+	defer zr.Close()
+
+	body, err := io.ReadAll(io.LimitReader(zr, MaxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress feed: %w", err)
+	}
+	if int64(len(body)) > MaxResponseSize {
+		return nil, fmt.Errorf("feed too large: decompressed size exceeds limit of %d bytes", MaxResponseSize)
+	}
+
+	return body, nil
+}
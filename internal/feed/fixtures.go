@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Fixture modes. In record mode every fetched feed body is saved to the
+// fixture directory; in replay mode feeds are read from it instead of the
+// network, so parsing, checks and the TUI can be exercised offline.
+var (
+	recordDir string
+	replayDir string
+)
+
+// SetRecordDir saves raw feed responses to dir. An empty dir turns
+// recording off.
+func SetRecordDir(dir string) {
+	recordDir = dir
+}
+
+// SetReplayDir serves feeds from responses previously recorded in dir
+// instead of fetching them. An empty dir turns replaying off.
+func SetReplayDir(dir string) {
+	replayDir = dir
+}
+
+// fixtureMode reports whether responses are being recorded or replayed,
+// in which case the feed cache is bypassed so every feed goes through
+// the fixtures
+func fixtureMode() bool {
+	return recordDir != "" || replayDir != ""
+}
+
+// fixturePath returns the file holding the recorded response for a URL
+func fixturePath(dir, url string) string {
+	return filepath.Join(dir, fmt.Sprintf("%x.feed", sha256.Sum256([]byte(url))))
+}
+
+// replay returns the recorded response for a URL
+func replay(url string) ([]byte, error) {
+	body, err := os.ReadFile(fixturePath(replayDir, url))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no recorded response for %s in %s", url, replayDir)
+		}
+		return nil, fmt.Errorf("failed to read recorded response: %w", err)
+	}
+	return body, nil
+}
+
+// record saves a fetched response for a URL
+func record(url string, body []byte) error {
+	if err := os.MkdirAll(recordDir, 0755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(fixturePath(recordDir, url), body, 0644); err != nil {
+		return fmt.Errorf("failed to record response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// archNewsURL is the feed the fixtures in testdata stand in for
+const archNewsURL = "https://archlinux.org/feeds/news/"
+
+// replayFixture sets up replaying testdata/name as the response for url
+func replayFixture(t *testing.T, url, name string) {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(fixturePath(dir, url), body, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	SetReplayDir(dir)
+	t.Cleanup(func() { SetReplayDir("") })
+}
+
+func TestReplayParsesFixture(t *testing.T) {
+	replayFixture(t, archNewsURL, "archnews.xml")
+
+	items, err := ParseFeed(archNewsURL)
+	if err != nil {
+		t.Fatalf("ParseFeed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if !strings.HasPrefix(items[0].Title, "Manual intervention for pacman 7.0.0") {
+		t.Errorf("first title = %q", items[0].Title)
+	}
+	if items[0].FeedURL != archNewsURL {
+		t.Errorf("FeedURL = %q, want %q", items[0].FeedURL, archNewsURL)
+	}
+	if items[1].Published.IsZero() {
+		t.Error("second item has no publication date")
+	}
+}
+
+func TestReplayWithoutRecording(t *testing.T) {
+	SetReplayDir(t.TempDir())
+	t.Cleanup(func() { SetReplayDir("") })
+
+	_, err := ParseFeed(archNewsURL)
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Fatalf("err = %v, want a missing recording", err)
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "archnews.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	SetRecordDir(dir)
+	t.Cleanup(func() { SetRecordDir("") })
+
+	recorded, err := ParseFeed(server.URL)
+	if err != nil {
+		t.Fatalf("ParseFeed while recording: %v", err)
+	}
+	SetRecordDir("")
+	server.Close()
+
+	SetReplayDir(dir)
+	t.Cleanup(func() { SetReplayDir("") })
+
+	replayed, err := ParseFeed(server.URL)
+	if err != nil {
+		t.Fatalf("ParseFeed while replaying: %v", err)
+	}
+	if len(replayed) != len(recorded) {
+		t.Fatalf("replayed %d items, recorded %d", len(replayed), len(recorded))
+	}
+	for i := range recorded {
+		if replayed[i].Title != recorded[i].Title {
+			t.Errorf("item %d: replayed %q, recorded %q", i, replayed[i].Title, recorded[i].Title)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Patterns for the Markdown produced by htmlToMarkdown
+var (
+	markdownLinkPattern  = regexp.MustCompile(`\[((?:\\.|[^\]\\])*)\]\(([^)\s]+)\)`)
+	markdownImagePattern = regexp.MustCompile(`!\[((?:\\.|[^\]\\])*)\]\([^)\s]+\)`)
+	headingPattern       = regexp.MustCompile(`(?m)^(> )*#{1,6} `)
+	fencePattern         = regexp.MustCompile("(?m)^(> )*```\\n?")
+)
+
+// ContentWithFootnotes returns the item content as plain text with a [N]
+// marker after each link, and the linked URLs in marker order. Links to the
+// same URL share a number, and links whose text is the URL itself get none.
+// Items without Markdown content are returned as-is.
+func (i Item) ContentWithFootnotes() (string, []string) {
+	if i.Markdown == "" {
+		return i.Content, nil
+	}
+
+	var urls []string
+	numbers := make(map[string]int)
+	footnote := func(link string) string {
+		match := markdownLinkPattern.FindStringSubmatch(link)
+		label, url := match[1], match[2]
+		if unescapeMarkdown(label) == url {
+			return label
+		}
+
+		n, ok := numbers[url]
+		if !ok {
+			urls = append(urls, url)
+			n = len(urls)
+			numbers[url] = n
+		}
+		return label + " [" + strconv.Itoa(n) + "]"
+	}
+
+	// Code blocks are kept verbatim, the text between them is unmarked
+	var b strings.Builder
+	for n, segment := range fencePattern.Split(i.Markdown, -1) {
+		if n%2 == 1 {
+			b.WriteString(segment)
+			continue
+		}
+		text := markdownImagePattern.ReplaceAllString(segment, "$1")
+		text = markdownLinkPattern.ReplaceAllStringFunc(text, footnote)
+		text = headingPattern.ReplaceAllString(text, "$1")
+		b.WriteString(unescapeMarkdown(text))
+	}
+
+	return strings.TrimSpace(b.String()), urls
+}
+
+// unescapeMarkdown drops emphasis and code markers and backslash escapes
+func unescapeMarkdown(text string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range text {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '*' || r == '`':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
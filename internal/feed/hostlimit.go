@@ -0,0 +1,85 @@
+package feed
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Politeness limits for feeds sharing a host, set from the config.
+// HostMaxConcurrency caps the requests in flight to one host, 0 for no cap,
+// and HostMinInterval spaces out the start of requests to it.
+var (
+	HostMaxConcurrency = 2
+	HostMinInterval    time.Duration
+)
+
+// hostLimiter holds the request slots and schedule of one host
+type hostLimiter struct {
+	slots chan struct{}
+
+	mu sync.Mutex
+	// next is the earliest time the next request may start
+	next time.Time
+}
+
+var (
+	hostLimitersMu sync.Mutex
+	hostLimiters   = make(map[string]*hostLimiter)
+)
+
+// limiterFor returns the limiter of host, created with the limits in effect
+// when the host is first fetched from
+func limiterFor(host string) *hostLimiter {
+	hostLimitersMu.Lock()
+	defer hostLimitersMu.Unlock()
+
+	host = strings.ToLower(host)
+	l, ok := hostLimiters[host]
+	if !ok {
+		l = &hostLimiter{}
+		if HostMaxConcurrency > 0 {
+			l.slots = make(chan struct{}, HostMaxConcurrency)
+		}
+		hostLimiters[host] = l
+	}
+	return l
+}
+
+// waitForHost blocks until a request to host may start, or ctx is done. The
+// returned function must be called once the response has been read.
+func waitForHost(ctx context.Context, host string) (func(), error) {
+	l := limiterFor(host)
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	l.mu.Lock()
+	start := time.Now()
+	if l.next.After(start) {
+		start = l.next
+	}
+	l.next = start.Add(HostMinInterval)
+	l.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,97 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// JSONFeed is a JSON Feed document (https://jsonfeed.org)
+type JSONFeed struct {
+	Version string         `json:"version"`
+	Items   []JSONFeedItem `json:"items"`
+}
+
+// JSONFeedItem is an item of a JSON Feed document
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Tags          []string         `json:"tags"`
+	Author        *JSONFeedAuthor  `json:"author"`
+	Authors       []JSONFeedAuthor `json:"authors"`
+}
+
+// JSONFeedAuthor is the author of a JSON Feed item
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// isJSONFeed reports whether body looks like a JSON Feed document
+func isJSONFeed(body []byte) bool {
+	trimmed := strings.TrimSpace(string(body))
+	return strings.HasPrefix(trimmed, "{") && strings.Contains(trimmed, "jsonfeed.org/version/")
+}
+
+func parseJSONFeed(data []byte) ([]Item, error) {
+	var feed JSONFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON Feed: %w", err)
+	}
+
+	var items []Item
+	for _, entry := range feed.Items {
+		dateStr := entry.DatePublished
+		if dateStr == "" {
+			dateStr = entry.DateModified
+		}
+		pubTime, err := parseTime(dateStr)
+		if err != nil {
+			continue
+		}
+
+		// Prefer HTML content, then text, then the summary
+		var content, markdown string
+		switch {
+		case entry.ContentHTML != "":
+			content = cleanHTML(entry.ContentHTML)
+			markdown = htmlToMarkdown(entry.ContentHTML)
+		case entry.ContentText != "":
+			content = strings.TrimSpace(entry.ContentText)
+		default:
+			content = strings.TrimSpace(entry.Summary)
+		}
+
+		// Version 1.1 replaced author with authors
+		var author string
+		if len(entry.Authors) > 0 {
+			author = entry.Authors[0].Name
+		} else if entry.Author != nil {
+			author = entry.Author.Name
+		}
+
+		id := entry.ID
+		if id == "" {
+			id = entry.URL
+		}
+
+		items = append(items, Item{
+			ID:        id,
+			Title:     html.UnescapeString(entry.Title),
+			Content:   content,
+			Published: pubTime,
+			Link:      entry.URL,
+			Author:    strings.TrimSpace(author),
+			Tags:      cleanTags(entry.Tags),
+			Markdown:  markdown,
+		})
+	}
+
+	return items, nil
+}
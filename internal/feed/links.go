@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"net/url"
+	"strings"
+)
+
+// resolveLinks makes the item link and the links and images in its content
+// absolute. Relative URLs in the content are resolved against the item link,
+// or against the feed URL when the item has no absolute link.
+func resolveLinks(item *Item, feedURL string) {
+	base, err := url.Parse(feedURL)
+	if err != nil || !base.IsAbs() {
+		return
+	}
+
+	item.Link = resolveURL(base, item.Link)
+	if link, err := url.Parse(item.Link); err == nil && link.IsAbs() {
+		base = link
+	}
+
+	if item.Markdown == "" {
+		return
+	}
+
+	// Code blocks are kept verbatim, links are only looked for between them
+	var b strings.Builder
+	last, code := 0, false
+	for _, fence := range fencePattern.FindAllStringIndex(item.Markdown, -1) {
+		segment := item.Markdown[last:fence[0]]
+		if !code {
+			segment = resolveMarkdownLinks(segment, base)
+		}
+		b.WriteString(segment + item.Markdown[fence[0]:fence[1]])
+		last, code = fence[1], !code
+	}
+	segment := item.Markdown[last:]
+	if !code {
+		segment = resolveMarkdownLinks(segment, base)
+	}
+	b.WriteString(segment)
+	item.Markdown = b.String()
+}
+
+// resolveMarkdownLinks resolves the targets of the links and images in
+// Markdown text against base
+func resolveMarkdownLinks(text string, base *url.URL) string {
+	return markdownLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownLinkPattern.FindStringSubmatch(link)
+		return "[" + match[1] + "](" + resolveURL(base, match[2]) + ")"
+	})
+}
+
+// resolveURL resolves ref against base, leaving absolute and unparsable
+// references as they are
+func resolveURL(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil || u.IsAbs() {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,259 @@
+package feed
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Patterns used to convert item HTML to Markdown
+var (
+	tagPattern     = regexp.MustCompile(`(?s)<!--.*?-->|<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	hrefPattern    = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	srcPattern     = regexp.MustCompile(`(?i)\bsrc\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	altPattern     = regexp.MustCompile(`(?i)\balt\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	scriptPattern  = regexp.MustCompile(`(?is)<script\b.*?</script>`)
+	stylePattern   = regexp.MustCompile(`(?is)<style\b.*?</style>`)
+	markdownEscape = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+)
+
+// htmlToMarkdown converts item HTML to Markdown for rendering in the
+// reader, keeping headings, emphasis, links, lists, quotes and code blocks
+func htmlToMarkdown(content string) string {
+	content = scriptPattern.ReplaceAllString(content, "")
+	content = stylePattern.ReplaceAllString(content, "")
+
+	w := &markdownWriter{lineStart: true}
+	last := 0
+	for _, match := range tagPattern.FindAllStringSubmatchIndex(content, -1) {
+		w.text(content[last:match[0]])
+		last = match[1]
+
+		if match[4] < 0 {
+			// Comment
+			continue
+		}
+		closing := match[3] > match[2]
+		name := strings.ToLower(content[match[4]:match[5]])
+		w.tag(name, closing, content[match[6]:match[7]])
+	}
+	w.text(content[last:])
+
+	return strings.TrimSpace(w.b.String())
+}
+
+// markdownList is an open HTML list
+type markdownList struct {
+	ordered bool
+	count   int
+}
+
+// markdownWriter builds Markdown while walking the HTML. Line breaks are
+// owed until the next text is written, so that nested block elements do
+// not pile up blank lines.
+type markdownWriter struct {
+	b         strings.Builder
+	breaks    int
+	lineStart bool
+	quote     int
+	pre       int
+	preStart  bool
+	lists     []markdownList
+	links     []string
+}
+
+// block ends the current paragraph
+func (w *markdownWriter) block() {
+	if w.lineStart {
+		return
+	}
+	if len(w.lists) > 0 {
+		// Paragraphs inside list items stay in the item
+		w.line()
+		return
+	}
+	if w.b.Len() > 0 && w.breaks < 2 {
+		w.breaks = 2
+	}
+}
+
+// line ends the current line
+func (w *markdownWriter) line() {
+	if !w.lineStart && w.b.Len() > 0 && w.breaks < 1 {
+		w.breaks = 1
+	}
+}
+
+// write outputs s after any owed line breaks, prefixing new lines inside
+// block quotes
+func (w *markdownWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	w.flush()
+	if w.lineStart && w.quote > 0 {
+		w.b.WriteString(strings.Repeat("> ", w.quote))
+	}
+	w.lineStart = false
+	w.b.WriteString(s)
+}
+
+// flush writes the owed line breaks
+func (w *markdownWriter) flush() {
+	for ; w.breaks > 0; w.breaks-- {
+		if w.breaks > 1 && w.quote > 0 {
+			// Blank line that stays inside the quote
+			w.b.WriteString("\n" + strings.Repeat(">", w.quote))
+		} else {
+			w.b.WriteString("\n")
+		}
+		w.lineStart = true
+	}
+}
+
+// text outputs text between tags
+func (w *markdownWriter) text(s string) {
+	s = html.UnescapeString(s)
+	if w.pre > 0 {
+		// Whitespace is kept as is, apart from the line breaks around the
+		// block, which would show as blank lines inside the fence
+		if w.preStart && s != "" {
+			s = strings.TrimPrefix(strings.TrimPrefix(s, "\r"), "\n")
+			w.preStart = false
+		}
+		trimmed := strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r")
+		w.write(trimmed)
+		if trimmed != s {
+			w.line()
+		}
+		return
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s != "" && !w.lineStart && w.breaks == 0 {
+			w.write(" ")
+		}
+		return
+	}
+
+	text := markdownEscape.Replace(strings.Join(fields, " "))
+	if strings.TrimLeft(s, " \t\r\n") != s && !w.lineStart && w.breaks == 0 {
+		text = " " + text
+	}
+	if strings.TrimRight(s, " \t\r\n") != s {
+		text += " "
+	}
+	w.write(text)
+}
+
+// tag outputs the Markdown for an opening or closing tag
+func (w *markdownWriter) tag(name string, closing bool, attrs string) {
+	if w.pre > 0 && name != "pre" {
+		return
+	}
+
+	switch name {
+	case "p", "div", "section", "article", "header", "footer", "table", "tr", "dl", "dd", "dt":
+		w.block()
+	case "br":
+		w.write("  ")
+		w.line()
+	case "hr":
+		w.block()
+		w.write("---")
+		w.block()
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.block()
+		if !closing {
+			w.write(strings.Repeat("#", int(name[1]-'0')) + " ")
+		}
+	case "strong", "b":
+		w.write("**")
+	case "em", "i":
+		w.write("*")
+	case "code", "tt":
+		w.write("`")
+	case "pre":
+		if closing {
+			w.pre--
+			w.line()
+			w.write("```")
+			w.block()
+		} else {
+			w.block()
+			w.write("```")
+			w.line()
+			w.pre++
+			w.preStart = true
+		}
+	case "blockquote":
+		// Breaks around the quote are not part of it
+		w.block()
+		if closing {
+			w.quote--
+			w.flush()
+		} else {
+			w.flush()
+			w.quote++
+		}
+	case "ul", "ol":
+		if closing {
+			if len(w.lists) > 0 {
+				w.lists = w.lists[:len(w.lists)-1]
+			}
+			w.block()
+		} else {
+			w.line()
+			w.lists = append(w.lists, markdownList{ordered: name == "ol"})
+		}
+	case "li":
+		if closing || len(w.lists) == 0 {
+			return
+		}
+		w.line()
+		list := &w.lists[len(w.lists)-1]
+		list.count++
+		marker := "- "
+		if list.ordered {
+			marker = strconv.Itoa(list.count) + ". "
+		}
+		w.write(strings.Repeat("    ", len(w.lists)-1) + marker)
+	case "a":
+		if closing {
+			if len(w.links) == 0 {
+				return
+			}
+			href := w.links[len(w.links)-1]
+			w.links = w.links[:len(w.links)-1]
+			if href != "" {
+				w.write("](" + href + ")")
+			}
+			return
+		}
+		href := attribute(hrefPattern, attrs)
+		w.links = append(w.links, href)
+		if href != "" {
+			w.write("[")
+		}
+	case "img":
+		if src := attribute(srcPattern, attrs); src != "" {
+			w.write("![" + markdownEscape.Replace(attribute(altPattern, attrs)) + "](" + src + ")")
+		}
+	}
+}
+
+// attribute returns the value of the attribute matched by pattern
+func attribute(pattern *regexp.Regexp, attrs string) string {
+	match := pattern.FindStringSubmatch(attrs)
+	if match == nil {
+		return ""
+	}
+	for _, value := range match[1:] {
+		if value != "" {
+			return html.UnescapeString(value)
+		}
+	}
+	return ""
+}
@@ -1,11 +1,11 @@
 package feed
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"html"
-	"net/http"
-	"os"
+	"informant/internal/logging"
 	"regexp"
 	"strings"
 	"time"
@@ -19,6 +19,27 @@ type Item struct {
 	Published time.Time `json:"published"`
 	Link      string    `json:"link"`
 	FeedName  string    `json:"feed_name"`
+	FeedURL   string    `json:"feed_url,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+
+	// Markdown is the content converted from HTML for rich rendering. It is
+	// empty for plain text content.
+	Markdown string `json:"markdown,omitempty"`
+
+	// Highlighted is set for items matching a highlight rule
+	Highlighted bool `json:"highlighted,omitempty"`
+	// Severity is the level given by the severity rules
+	Severity string `json:"severity,omitempty"`
+}
+
+// Key returns the key under which the item's state is stored. Keys are
+// namespaced by feed URL so that feeds reusing GUIDs do not collide.
+func (i Item) Key() string {
+	if i.FeedURL == "" {
+		return i.ID
+	}
+	return i.FeedURL + "#" + i.ID
 }
 
 // RSS structs for parsing RSS feeds
@@ -31,11 +52,14 @@ type Channel struct {
 }
 
 type RSSItem struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
-	Link        string `xml:"link"`
-	GUID        string `xml:"guid"`
+	Title       string   `xml:"title"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
 }
 
 // Atom structs for parsing Atom feeds
@@ -57,6 +81,12 @@ type AtomEntry struct {
 	Published string     `xml:"published"`
 	Updated   string     `xml:"updated"`
 	Links     []AtomLink `xml:"link"`
+	Author    struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
 }
 
 type AtomLink struct {
@@ -77,62 +107,114 @@ func ParseFeed(url string) ([]Item, error) {
 
 // ParseFeedWithStorage fetches and parses an RSS or Atom feed with optional caching
 func ParseFeedWithStorage(url string, storage CacheStorage) ([]Item, error) {
+	return ParseFeedWithContext(context.Background(), url, storage)
+}
+
+// ParseFeedWithContext fetches and parses an RSS or Atom feed with optional
+// caching, giving up when ctx is done. A Timing set with WithTiming is
+// filled in.
+func ParseFeedWithContext(ctx context.Context, url string, storage CacheStorage) ([]Item, error) {
 	var body []byte
 
+	timing := timingFrom(ctx)
+	if timing != nil {
+		start := time.Now()
+		defer func() { timing.Total = time.Since(start) }()
+	}
+
 	// Try to get from cache first if storage is provided
-	if storage != nil {
-		if cachedData, found := storage.GetCacheFile(url, 15*time.Minute); found {
+	if storage != nil && !fixtureMode() {
+		if cachedData, found := storage.GetCacheFile(url, CacheTTL); found {
+			logging.Event(logging.LevelDebug, "Cache hit", logging.Fields{"url": url})
 			body = cachedData
+			if timing != nil {
+				timing.Cached = true
+			}
 		}
 	}
 
 	// If we don't have cached data, fetch from HTTP
 	if body == nil {
-		resp, err := http.Get(url)
+		var err error
+		hint := &cacheHint{}
+		body, err = fetch(withCacheHint(ctx, hint), url)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch feed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-
-		// Read response body
-		buf := make([]byte, 1024)
-		for {
-			n, err := resp.Body.Read(buf)
-			if n > 0 {
-				body = append(body, buf[:n]...)
-			}
-			if err != nil {
-				break
-			}
+			return nil, err
 		}
 
 		// Cache the data if storage is provided
-		if storage != nil {
-			if err := storage.SetCacheFile(url, body); err != nil {
+		if storage != nil && !fixtureMode() {
+			if err := setCache(storage, url, body, hint); err != nil {
 				// Don't fail on cache errors, just log and continue
-				fmt.Fprintf(os.Stderr, "Warning: Failed to cache feed data: %v\n", err)
+				logging.Warnf("Failed to cache feed data: %v", err)
 			}
 		}
 	}
+	if timing != nil {
+		timing.Bytes = len(body)
+	}
+
+	body, err := decompress(body)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].FeedURL = url
+		resolveLinks(&items[i], url)
+	}
 
-	// Try to determine if it's RSS or Atom by looking at the content
-	bodyStr := string(body)
-	if strings.Contains(bodyStr, "<rss") || strings.Contains(bodyStr, "<channel") {
-		return parseRSS(body)
-	} else if strings.Contains(bodyStr, "<feed") || strings.Contains(bodyStr, "atom") {
-		return parseAtom(body)
+	return process(ctx, items)
+}
+
+// setCache stores fetched feed data in the cache, to be kept as long as the
+// server allows when the storage supports it
+func setCache(storage CacheStorage, url string, body []byte, hint *cacheHint) error {
+	expiring, ok := storage.(ExpiringCacheStorage)
+	ttl, bounded := hint.bounded()
+	if !ok || !bounded {
+		return storage.SetCacheFile(url, body)
+	}
+	logging.Event(logging.LevelDebug, "Cache lifetime from server", logging.Fields{"url": url, "ttl": ttl.String()})
+	return expiring.SetCacheFileUntil(url, body, time.Now().Add(ttl))
+}
+
+// Parser branches chosen by ParseBody
+const (
+	FormatRSS          = "RSS (found <rss> or <channel>)"
+	FormatAtom         = "Atom (found <feed> or \"atom\")"
+	FormatRSSFallback  = "RSS (no marker found, RSS parse yielded items)"
+	FormatAtomFallback = "Atom (no marker found, RSS parse yielded no items)"
+	FormatJSONFeed     = "JSON Feed (found a jsonfeed.org version)"
+)
+
+// parse decodes feed data as RSS or Atom
+func parse(body []byte) ([]Item, error) {
+	items, _, err := ParseBody(body)
+	return items, err
+}
+
+// ParseBody decodes uncompressed feed data as RSS or Atom and reports which
+// parser branch was chosen
+func ParseBody(body []byte) ([]Item, string, error) {
+	// Let the registered parsers determine the format from the content
+	if p := detectParser(body); p != nil {
+		items, err := p.Parse(body)
+		return items, p.Name(), err
 	}
 
 	// Default to trying RSS first, then Atom
 	if items, err := parseRSS(body); err == nil && len(items) > 0 {
-		return items, nil
+		return items, FormatRSSFallback, nil
 	}
 
-	return parseAtom(body)
+	items, err := parseAtom(body)
+	return items, FormatAtomFallback, err
 }
 
 func parseRSS(data []byte) ([]Item, error) {
@@ -159,12 +241,21 @@ func parseRSS(data []byte) ([]Item, error) {
 			id = rssItem.Link
 		}
 
+		// Prefer dc:creator, since RSS authors are meant to be email addresses
+		author := rssItem.Creator
+		if author == "" {
+			author = rssItem.Author
+		}
+
 		item := Item{
 			ID:        id,
 			Title:     html.UnescapeString(rssItem.Title),
 			Content:   content,
 			Published: pubTime,
 			Link:      rssItem.Link,
+			Author:    strings.TrimSpace(author),
+			Tags:      cleanTags(rssItem.Categories),
+			Markdown:  htmlToMarkdown(rssItem.Description),
 		}
 
 		items = append(items, item)
@@ -192,9 +283,13 @@ func parseAtom(data []byte) ([]Item, error) {
 		}
 
 		// Get content - prefer content over summary
-		content := entry.Content.Content
+		content, contentType := entry.Content.Content, entry.Content.Type
 		if content == "" {
-			content = entry.Summary.Content
+			content, contentType = entry.Summary.Content, entry.Summary.Type
+		}
+		var markdown string
+		if contentType != "text" {
+			markdown = htmlToMarkdown(content)
 		}
 		content = cleanHTML(content)
 
@@ -207,12 +302,20 @@ func parseAtom(data []byte) ([]Item, error) {
 			}
 		}
 
+		var tags []string
+		for _, category := range entry.Categories {
+			tags = append(tags, category.Term)
+		}
+
 		item := Item{
 			ID:        entry.ID,
 			Title:     html.UnescapeString(entry.Title),
 			Content:   content,
 			Published: pubTime,
 			Link:      link,
+			Author:    strings.TrimSpace(entry.Author.Name),
+			Tags:      cleanTags(tags),
+			Markdown:  markdown,
 		}
 
 		items = append(items, item)
@@ -221,6 +324,17 @@ func parseAtom(data []byte) ([]Item, error) {
 	return items, nil
 }
 
+// cleanTags trims category names and drops empty ones
+func cleanTags(categories []string) []string {
+	var tags []string
+	for _, category := range categories {
+		if tag := strings.TrimSpace(html.UnescapeString(category)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // parseTime attempts to parse various time formats commonly used in feeds
 func parseTime(timeStr string) (time.Time, error) {
 	timeStr = strings.TrimSpace(timeStr)
@@ -253,10 +367,26 @@ func parseTime(timeStr string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unable to parse time: %s", timeStr)
 }
 
-// cleanHTML removes HTML tags and cleans up content for display
+// preBlockPattern matches preformatted blocks, such as shell commands
+var preBlockPattern = regexp.MustCompile(`(?is)<pre\b[^>]*>(.*?)</pre>`)
+
+// cleanHTML removes HTML tags and cleans up content for display.
+// Preformatted blocks keep their exact whitespace and line breaks, so
+// commands in them can be copied safely.
 func cleanHTML(content string) string {
-	// Remove HTML tags
 	re := regexp.MustCompile(`<[^>]*>`)
+
+	// Set preformatted blocks aside, on lines of their own, while the rest
+	// is cleaned up
+	var blocks []string
+	content = preBlockPattern.ReplaceAllStringFunc(content, func(pre string) string {
+		block := preBlockPattern.FindStringSubmatch(pre)[1]
+		block = html.UnescapeString(re.ReplaceAllString(block, ""))
+		blocks = append(blocks, trimPreformatted(block))
+		return fmt.Sprintf("\n\n\x00%d\x00\n\n", len(blocks)-1)
+	})
+
+	// Remove HTML tags
 	content = re.ReplaceAllString(content, "")
 
 	// Unescape HTML entities
@@ -269,5 +399,18 @@ func cleanHTML(content string) string {
 	re = regexp.MustCompile(`\n\s*\n\s*\n`)
 	content = re.ReplaceAllString(content, "\n\n")
 
+	for i, block := range blocks {
+		content = strings.Replace(content, fmt.Sprintf("\x00%d\x00", i), block, 1)
+	}
+
 	return content
 }
+
+// trimPreformatted drops the line break that browsers ignore after <pre> and
+// the one before </pre>, keeping all other whitespace
+func trimPreformatted(text string) string {
+	text = strings.TrimPrefix(text, "\r")
+	text = strings.TrimPrefix(text, "\n")
+	text = strings.TrimSuffix(text, "\n")
+	return strings.TrimSuffix(text, "\r")
+}
@@ -0,0 +1,157 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Fetcher retrieves the raw feed data for URLs of a scheme
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// FetcherFunc adapts a function to the Fetcher interface
+type FetcherFunc func(ctx context.Context, url string) ([]byte, error)
+
+// Fetch calls f(ctx, url)
+func (f FetcherFunc) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+// Parser decodes feed data of one format
+type Parser interface {
+	// Name describes the format, as reported by 'informant fetch --parsed'
+	Name() string
+	// Detect reports whether the data looks like this format
+	Detect(body []byte) bool
+	Parse(body []byte) ([]Item, error)
+}
+
+// Processor post-processes the items of every fetched feed, for example to
+// rewrite or drop them
+type Processor interface {
+	Name() string
+	Process(ctx context.Context, items []Item) ([]Item, error)
+}
+
+// The registries are read by concurrent fetches and written while loading
+// the config
+var (
+	pluginsMu  sync.RWMutex
+	fetchers   = make(map[string]Fetcher)
+	parsers    []Parser
+	processors []Processor
+)
+
+// RegisterFetcher makes url fetches with the given scheme, such as "https"
+// or "exec", use f, replacing any fetcher registered for it before
+func RegisterFetcher(scheme string, f Fetcher) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	fetchers[strings.ToLower(scheme)] = f
+}
+
+// RegisterParser adds a feed format. Formats are detected in the order
+// they were registered, and a parser with the same name is replaced.
+func RegisterParser(p Parser) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	for i, existing := range parsers {
+		if existing.Name() == p.Name() {
+			parsers[i] = p
+			return
+		}
+	}
+	parsers = append(parsers, p)
+}
+
+// RegisterProcessor adds a post-processing step, run in the order of
+// registration. A processor with the same name is replaced.
+func RegisterProcessor(p Processor) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	for i, existing := range processors {
+		if existing.Name() == p.Name() {
+			processors[i] = p
+			return
+		}
+	}
+	processors = append(processors, p)
+}
+
+// urlScheme returns the lowercased scheme of url, "" when it has none
+func urlScheme(url string) string {
+	i := strings.Index(url, ":")
+	if i <= 0 {
+		return ""
+	}
+	return strings.ToLower(url[:i])
+}
+
+// fetcherFor returns the fetcher registered for the scheme of url
+func fetcherFor(url string) (Fetcher, error) {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	if f, ok := fetchers[urlScheme(url)]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported feed URL %q: no fetcher for its scheme", url)
+}
+
+// detectParser returns the first registered parser recognizing body
+func detectParser(body []byte) Parser {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	for _, p := range parsers {
+		if p.Detect(body) {
+			return p
+		}
+	}
+	return nil
+}
+
+// process runs the registered processors over items
+func process(ctx context.Context, items []Item) ([]Item, error) {
+	pluginsMu.RLock()
+	steps := append([]Processor(nil), processors...)
+	pluginsMu.RUnlock()
+
+	for _, p := range steps {
+		var err error
+		if items, err = p.Process(ctx, items); err != nil {
+			return nil, fmt.Errorf("processor %s failed: %w", p.Name(), err)
+		}
+	}
+	return items, nil
+}
+
+// builtinParser is a Parser made of the built-in format functions
+type builtinParser struct {
+	name   string
+	detect func(body []byte) bool
+	parse  func(body []byte) ([]Item, error)
+}
+
+func (p builtinParser) Name() string                      { return p.name }
+func (p builtinParser) Detect(body []byte) bool           { return p.detect(body) }
+func (p builtinParser) Parse(body []byte) ([]Item, error) { return p.parse(body) }
+
+// The built-in sources and formats are registered like any plugin
+func init() {
+	RegisterFetcher("http", FetcherFunc(fetchHTTP))
+	RegisterFetcher("https", FetcherFunc(fetchHTTP))
+	RegisterFetcher(strings.TrimSuffix(ExecScheme, ":"), FetcherFunc(fetchExec))
+
+	// JSON Feed is checked first, as its content may well mention <rss
+	RegisterParser(builtinParser{name: FormatJSONFeed, detect: isJSONFeed, parse: parseJSONFeed})
+	RegisterParser(builtinParser{name: FormatRSS, detect: func(body []byte) bool {
+		s := string(body)
+		return strings.Contains(s, "<rss") || strings.Contains(s, "<channel")
+	}, parse: parseRSS})
+	RegisterParser(builtinParser{name: FormatAtom, detect: func(body []byte) bool {
+		s := string(body)
+		return strings.Contains(s, "<feed") || strings.Contains(s, "atom")
+	}, parse: parseAtom})
+}
@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"time"
+)
+
+// Timing records where the time fetching a feed went. Phases that did not
+// happen, such as DNS for a reused connection or anything for a cache hit,
+// stay zero.
+type Timing struct {
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	// TTFB is the time from sending the request to the first response byte
+	TTFB time.Duration
+	// Total covers fetching, or reading the cache, and parsing
+	Total  time.Duration
+	Bytes  int
+	Cached bool
+}
+
+type timingKey struct{}
+
+// WithTiming returns a context under which ParseFeedWithContext records its
+// timing into t
+func WithTiming(ctx context.Context, t *Timing) context.Context {
+	return context.WithValue(ctx, timingKey{}, t)
+}
+
+// timingFrom returns the timing to record into, nil if none was asked for
+func timingFrom(ctx context.Context) *Timing {
+	t, _ := ctx.Value(timingKey{}).(*Timing)
+	return t
+}
+
+// traceTiming returns a context that records the phases of an HTTP request
+// into t
+func traceTiming(ctx context.Context, t *Timing) context.Context {
+	var dnsStart, connectStart, tlsStart, requestStart time.Time
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.DNS = time.Since(dnsStart) },
+		ConnectStart: func(string, string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			t.Connect = time.Since(connectStart)
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.TLS = time.Since(tlsStart)
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) { requestStart = time.Now() },
+		GotFirstResponseByte: func() {
+			t.TTFB = time.Since(requestStart)
+		},
+	})
+}
@@ -0,0 +1,221 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Report describes how a feed document parses, for feed maintainers and for
+// finding out why a feed shows nothing
+type Report struct {
+	// Format is the parser branch chosen, as reported by ParseBody
+	Format string
+	// Entries is the number of entries in the document, Items how many of
+	// them were parsed
+	Entries int
+	Items   int
+	// ParseError is set when the document could not be parsed at all
+	ParseError error
+
+	// BadDates lists the entries dropped because their date is missing or
+	// could not be parsed
+	BadDates []string
+	// MissingGUIDs counts entries without an ID of their own, whose link is
+	// used instead
+	MissingGUIDs int
+	// MissingIDs counts entries with neither ID nor link, whose read status
+	// cannot be tracked reliably
+	MissingIDs int
+	// Encoding lists problems with the character encoding of the document
+	Encoding []string
+	// DoubleEscaped counts entries whose title is HTML-escaped twice
+	DoubleEscaped int
+	// ContentFields counts the entries using each content field. Fields
+	// informant does not read are marked as unused.
+	ContentFields map[string]int
+}
+
+// Problems reports whether anything in the report needs fixing
+func (r Report) Problems() bool {
+	return r.ParseError != nil || len(r.BadDates) > 0 || r.MissingGUIDs > 0 || r.MissingIDs > 0 ||
+		len(r.Encoding) > 0 || r.DoubleEscaped > 0
+}
+
+// lintEntry is the part of an entry Validate looks at, whatever its format
+type lintEntry struct {
+	title, date, id, link string
+	content               []string
+}
+
+// xmlEncoding matches the encoding in an XML declaration
+var xmlEncoding = regexp.MustCompile(`^\s*<\?xml[^>]*encoding=["']([^"']+)["']`)
+
+// Validate parses an uncompressed feed document and reports what informant
+// makes of it, entry by entry
+func Validate(body []byte) Report {
+	items, format, err := ParseBody(body)
+	report := Report{
+		Format:        format,
+		Items:         len(items),
+		ParseError:    err,
+		ContentFields: make(map[string]int),
+	}
+
+	if format == FormatJSONFeed {
+		if !utf8.Valid(body) {
+			report.Encoding = append(report.Encoding, "the document is not valid UTF-8, as JSON Feed requires")
+		}
+	} else {
+		report.Encoding = xmlEncodingProblems(body)
+	}
+
+	var entries []lintEntry
+	switch format {
+	case FormatRSS, FormatRSSFallback:
+		entries = lintRSS(body)
+	case FormatAtom, FormatAtomFallback:
+		entries = lintAtom(body)
+	case FormatJSONFeed:
+		entries = lintJSONFeed(body)
+	default:
+		// Parser plugins are opaque, only their result is known
+		report.Entries = len(items)
+		return report
+	}
+
+	report.Entries = len(entries)
+	for _, entry := range entries {
+		if _, err := parseTime(entry.date); err != nil {
+			report.BadDates = append(report.BadDates, fmt.Sprintf("%q (date %q)", entry.title, entry.date))
+		}
+		switch {
+		case entry.id == "" && entry.link == "":
+			report.MissingIDs++
+		case entry.id == "":
+			report.MissingGUIDs++
+		}
+		if unescaped := html.UnescapeString(entry.title); unescaped != html.UnescapeString(unescaped) {
+			report.DoubleEscaped++
+		}
+		for _, field := range entry.content {
+			report.ContentFields[field]++
+		}
+	}
+
+	return report
+}
+
+// xmlEncodingProblems reports encoding problems of an XML document
+func xmlEncodingProblems(body []byte) []string {
+	var problems []string
+	if bytes.HasPrefix(body, []byte("\xef\xbb\xbf")) {
+		problems = append(problems, "the document starts with a UTF-8 byte order mark")
+		body = body[3:]
+	}
+	if m := xmlEncoding.FindSubmatch(body); m != nil {
+		if name := strings.ToLower(string(m[1])); name != "utf-8" && name != "utf8" {
+			problems = append(problems, fmt.Sprintf("the document declares encoding %s, but only UTF-8 is supported", m[1]))
+		}
+	}
+	if !utf8.Valid(body) {
+		problems = append(problems, "the document is not valid UTF-8")
+	} else if bytes.ContainsRune(body, utf8.RuneError) {
+		problems = append(problems, "the document contains replacement characters (U+FFFD), text was mis-decoded before publishing")
+	}
+	return problems
+}
+
+func lintRSS(body []byte) []lintEntry {
+	var doc struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			PubDate     string `xml:"pubDate"`
+			GUID        string `xml:"guid"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+		} `xml:"channel>item"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+
+	entries := make([]lintEntry, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		entry := lintEntry{title: item.Title, date: item.PubDate, id: item.GUID, link: item.Link}
+		if item.Description != "" {
+			entry.content = append(entry.content, "description")
+		}
+		if item.Encoded != "" {
+			entry.content = append(entry.content, "content:encoded (unused)")
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func lintAtom(body []byte) []lintEntry {
+	var feed Feed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil
+	}
+
+	entries := make([]lintEntry, 0, len(feed.Entries))
+	for _, atomEntry := range feed.Entries {
+		// Unlike in RSS, links do not stand in for a missing ID
+		entry := lintEntry{title: atomEntry.Title, date: atomEntry.Published, id: atomEntry.ID}
+		if entry.date == "" {
+			entry.date = atomEntry.Updated
+		}
+
+		switch {
+		case atomEntry.Content.Content != "":
+			entry.content = append(entry.content, "content")
+			if atomEntry.Summary.Content != "" {
+				entry.content = append(entry.content, "summary (unused)")
+			}
+		case atomEntry.Summary.Content != "":
+			entry.content = append(entry.content, "summary")
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func lintJSONFeed(body []byte) []lintEntry {
+	var feed JSONFeed
+	if err := json.Unmarshal(body, &feed); err != nil {
+		return nil
+	}
+
+	entries := make([]lintEntry, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		entry := lintEntry{title: item.Title, date: item.DatePublished, id: item.ID, link: item.URL}
+		if entry.date == "" {
+			entry.date = item.DateModified
+		}
+		// The first of these present is used
+		for _, field := range []struct{ name, value string }{
+			{"content_html", item.ContentHTML},
+			{"content_text", item.ContentText},
+			{"summary", item.Summary},
+		} {
+			if field.value == "" {
+				continue
+			}
+			name := field.name
+			if len(entry.content) > 0 {
+				name += " (unused)"
+			}
+			entry.content = append(entry.content, name)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
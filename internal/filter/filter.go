@@ -0,0 +1,63 @@
+package filter
+
+import (
+	"informant/internal/config"
+	"informant/internal/feed"
+	"regexp"
+)
+
+// rule is a compiled config.Rule
+type rule struct {
+	pattern *regexp.Regexp
+	field   string
+	feed    string
+}
+
+// Matcher matches items against a set of keyword rules
+type Matcher struct {
+	rules []rule
+}
+
+// New compiles rules into a Matcher
+func New(rules []config.Rule) (*Matcher, error) {
+	m := &Matcher{}
+	for _, r := range rules {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.rules = append(m.rules, rule{
+			pattern: pattern,
+			field:   r.Field,
+			feed:    r.Feed,
+		})
+	}
+	return m, nil
+}
+
+// Match reports whether any rule matches the item. Rules restricted to a
+// feed only apply to items from that feed; rules without a field match
+// either the title or the content.
+func (m *Matcher) Match(item feed.Item) bool {
+	for _, r := range m.rules {
+		if r.feed != "" && r.feed != item.FeedName {
+			continue
+		}
+
+		switch r.field {
+		case "title":
+			if r.pattern.MatchString(item.Title) {
+				return true
+			}
+		case "content":
+			if r.pattern.MatchString(item.Content) {
+				return true
+			}
+		default:
+			if r.pattern.MatchString(item.Title) || r.pattern.MatchString(item.Content) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package filter
+
+import (
+	"informant/internal/config"
+	"informant/internal/feed"
+)
+
+// leveledMatcher matches the items of one severity level
+type leveledMatcher struct {
+	level   string
+	matcher *Matcher
+}
+
+// Classifier assigns severity levels to items
+type Classifier struct {
+	levels []leveledMatcher
+}
+
+// NewClassifier compiles severity rules into a Classifier
+func NewClassifier(rules []config.SeverityRule) (*Classifier, error) {
+	c := &Classifier{}
+	for _, r := range rules {
+		matcher, err := New([]config.Rule{r.Rule})
+		if err != nil {
+			return nil, err
+		}
+		c.levels = append(c.levels, leveledMatcher{level: r.Level, matcher: matcher})
+	}
+	return c, nil
+}
+
+// Classify returns the most severe level of the rules matching the item.
+// Items matching none are warnings when highlighted and info otherwise.
+func (c *Classifier) Classify(item feed.Item) string {
+	level := config.SeverityInfo
+	if item.Highlighted {
+		level = config.SeverityWarning
+	}
+
+	for _, l := range c.levels {
+		if config.SeverityRank(l.level) > config.SeverityRank(level) && l.matcher.Match(item) {
+			level = l.level
+		}
+	}
+	return level
+}
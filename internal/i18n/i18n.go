@@ -0,0 +1,136 @@
+// Package i18n translates user-facing messages. Messages are identified by
+// their English text, as with gettext, so untranslated messages fall back to
+// English and the code stays readable.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// locales holds the catalogs shipped with informant
+//
+//go:embed locales/*.json
+var locales embed.FS
+
+// LocaleDir holds additional or overriding catalogs, named <language>.json,
+// so distributions can ship translations without rebuilding informant
+var LocaleDir = "/usr/share/informant/locale"
+
+var (
+	catalog map[string]string
+	mutex   sync.RWMutex
+)
+
+// Detect returns the language to use: the configured one, or else the first
+// of LC_ALL, LC_MESSAGES and LANG that is set
+func Detect(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// SetLanguage selects the catalog for a language such as "de", "pt_BR" or
+// "de_DE.UTF-8". The most specific catalog available is used; English, "C"
+// and "POSIX" need none.
+func SetLanguage(language string) error {
+	var selected map[string]string
+	for _, name := range candidates(language) {
+		messages, err := loadCatalog(name)
+		if err != nil {
+			return err
+		}
+		if messages != nil {
+			selected = messages
+			break
+		}
+	}
+
+	mutex.Lock()
+	catalog = selected
+	mutex.Unlock()
+	return nil
+}
+
+// candidates returns the catalog names to try for a language, most specific
+// first, e.g. "pt_BR" and "pt" for "pt_BR.UTF-8@euro"
+func candidates(language string) []string {
+	if i := strings.IndexAny(language, ".@"); i >= 0 {
+		language = language[:i]
+	}
+	if language == "" || language == "C" || language == "POSIX" {
+		return nil
+	}
+
+	names := []string{language}
+	if i := strings.IndexAny(language, "_-"); i >= 0 {
+		names = append(names, language[:i])
+	}
+	return names
+}
+
+// loadCatalog reads a catalog from LocaleDir or the shipped ones, returning
+// nil when neither has it. Shipped entries missing from an installed
+// catalog are kept.
+func loadCatalog(name string) (map[string]string, error) {
+	var messages map[string]string
+
+	if data, err := locales.ReadFile("locales/" + name + ".json"); err == nil {
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse catalog %s: %w", name, err)
+		}
+	}
+
+	path := filepath.Join(LocaleDir, name+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return messages, nil
+		}
+		return nil, fmt.Errorf("failed to read catalog %s: %w", path, err)
+	}
+
+	var installed map[string]string
+	if err := json.Unmarshal(data, &installed); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog %s: %w", path, err)
+	}
+	if messages == nil {
+		return installed, nil
+	}
+	for message, translation := range installed {
+		messages[message] = translation
+	}
+	return messages, nil
+}
+
+// T translates a message and formats it with args like fmt.Sprintf
+func T(message string, args ...interface{}) string {
+	mutex.RLock()
+	if translation, ok := catalog[message]; ok && translation != "" {
+		message = translation
+	}
+	mutex.RUnlock()
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// IsYes reports whether an answer to a yes/no prompt means yes, accepting
+// English and translated answers
+func IsYes(answer string) bool {
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes" || answer == strings.ToLower(T("y")) || answer == strings.ToLower(T("yes"))
+}
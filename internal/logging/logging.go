@@ -0,0 +1,235 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log message
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the lowercase name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// ParseLevel parses a level name as accepted by --log-level
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelWarn, fmt.Errorf("unknown log level %q: must be debug, info, warn or error", name)
+	}
+}
+
+// Log formats accepted by --log-format
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Fields are structured attributes attached to a log event
+type Fields map[string]interface{}
+
+// logger writes messages at or above its level to stderr, and with
+// timestamps to an optional log file
+type logger struct {
+	mutex  sync.Mutex
+	level  Level
+	format string
+	stderr io.Writer
+	file   *os.File
+}
+
+// std is the process-wide logger. Until Setup is called it writes warnings
+// and errors to stderr.
+var std = &logger{level: LevelWarn, format: FormatText, stderr: os.Stderr}
+
+// Setup sets the minimum level and output format and, when logFile is not
+// empty, appends every logged message to that file as well
+func Setup(level Level, format string, logFile string) error {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+
+	switch format {
+	case FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("unknown log format %q: must be text or json", format)
+	}
+
+	std.level = level
+	std.format = format
+
+	if logFile != "" {
+		file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		std.file = file
+	}
+
+	return nil
+}
+
+// Close closes the log file, if any
+func Close() error {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+
+	if std.file == nil {
+		return nil
+	}
+	err := std.file.Close()
+	std.file = nil
+	return err
+}
+
+// Enabled reports whether messages at the given level are logged
+func Enabled(level Level) bool {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+
+	return level >= std.level
+}
+
+// prefixes are shown before stderr messages, matching the wording of the
+// messages informant has always printed
+var prefixes = map[Level]string{
+	LevelDebug: "Debug: ",
+	LevelInfo:  "",
+	LevelWarn:  "Warning: ",
+	LevelError: "Error: ",
+}
+
+func (l *logger) log(level Level, message string, fields Fields) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if level < l.level {
+		return
+	}
+
+	message = strings.TrimRight(message, "\n")
+	now := time.Now()
+
+	if l.format == FormatJSON {
+		line := l.jsonLine(now, level, message, fields)
+		fmt.Fprintln(l.stderr, line)
+		if l.file != nil {
+			fmt.Fprintln(l.file, line)
+		}
+		return
+	}
+
+	text := message + formatFields(fields)
+	fmt.Fprintf(l.stderr, "%s%s\n", prefixes[level], text)
+	if l.file != nil {
+		fmt.Fprintf(l.file, "%s %-5s %s\n", now.Format(time.RFC3339), strings.ToUpper(level.String()), text)
+	}
+}
+
+// jsonLine encodes an event as a single JSON object. Fields cannot override
+// the time, level and msg keys.
+func (l *logger) jsonLine(now time.Time, level Level, message string, fields Fields) string {
+	event := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		event[key] = value
+	}
+	event["time"] = now.Format(time.RFC3339Nano)
+	event["level"] = level.String()
+	event["msg"] = message
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{
+			"time":  event["time"].(string),
+			"level": level.String(),
+			"msg":   message,
+			"error": fmt.Sprintf("failed to encode log fields: %v", err),
+		})
+	}
+	return string(data)
+}
+
+// formatFields renders fields as sorted key=value pairs for text output
+func formatFields(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		value := fmt.Sprint(fields[key])
+		if strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	}
+	return b.String()
+}
+
+// Event logs a message with structured fields. In JSON format the fields
+// become keys of the event object; in text format they are appended as
+// key=value pairs.
+func Event(level Level, message string, fields Fields) {
+	std.log(level, message, fields)
+}
+
+// Debugf logs a debug message
+func Debugf(format string, args ...interface{}) {
+	std.log(LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+// Infof logs an informational message
+func Infof(format string, args ...interface{}) {
+	std.log(LevelInfo, fmt.Sprintf(format, args...), nil)
+}
+
+// Warnf logs a warning
+func Warnf(format string, args ...interface{}) {
+	std.log(LevelWarn, fmt.Sprintf(format, args...), nil)
+}
+
+// Errorf logs an error
+func Errorf(format string, args ...interface{}) {
+	std.log(LevelError, fmt.Sprintf(format, args...), nil)
+}
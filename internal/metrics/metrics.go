@@ -0,0 +1,166 @@
+// Package metrics writes feed metrics in the Prometheus text format, for the
+// /metrics endpoint of serve and node_exporter's textfile collector in watch
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the content type of the Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// durationBuckets are the upper bounds, in seconds, of the fetch duration
+// histogram buckets
+var durationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// histogram counts observations into cumulative buckets
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+var (
+	mu          sync.Mutex
+	fetchErrors = make(map[string]uint64)
+	durations   = make(map[string]*histogram)
+)
+
+// Feed is the state of a feed when metrics are written
+type Feed struct {
+	Name        string
+	LastSuccess time.Time
+	// Unread counts unread items by severity, "" for items without one
+	Unread map[string]int
+}
+
+// ObserveFetch records how long fetching a feed took and whether it failed.
+// Counts are kept for the life of the process.
+func ObserveFetch(feed string, duration time.Duration, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err != nil {
+		fetchErrors[feed]++
+	}
+
+	h, ok := durations[feed]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(durationBuckets))}
+		durations[feed] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Write writes the metrics for feeds in the Prometheus text format
+func Write(w io.Writer, feeds []Feed) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	b := bufio.NewWriter(w)
+
+	header(b, "informant_unread_items", "gauge", "Unread news items that are not snoozed or queued to read later.")
+	for _, f := range feeds {
+		severities := make([]string, 0, len(f.Unread))
+		for severity := range f.Unread {
+			severities = append(severities, severity)
+		}
+		sort.Strings(severities)
+		// Feeds without unread items still get a series, so alerts see 0
+		if len(severities) == 0 {
+			severities = []string{""}
+		}
+		for _, severity := range severities {
+			fmt.Fprintf(b, "informant_unread_items{feed=%s,severity=%s} %d\n", label(f.Name), label(severity), f.Unread[severity])
+		}
+	}
+
+	header(b, "informant_feed_last_success_timestamp_seconds", "gauge", "Unix time of the last successful fetch of the feed.")
+	for _, f := range feeds {
+		if f.LastSuccess.IsZero() {
+			continue
+		}
+		fmt.Fprintf(b, "informant_feed_last_success_timestamp_seconds{feed=%s} %d\n", label(f.Name), f.LastSuccess.Unix())
+	}
+
+	header(b, "informant_feed_fetch_errors_total", "counter", "Failed fetches of the feed since the process started.")
+	for _, f := range feeds {
+		fmt.Fprintf(b, "informant_feed_fetch_errors_total{feed=%s} %d\n", label(f.Name), fetchErrors[f.Name])
+	}
+
+	header(b, "informant_feed_fetch_duration_seconds", "histogram", "Time taken to fetch and parse the feed.")
+	for _, f := range feeds {
+		h, ok := durations[f.Name]
+		if !ok {
+			continue
+		}
+		name := label(f.Name)
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(b, "informant_feed_fetch_duration_seconds_bucket{feed=%s,le=%q} %d\n", name, strconv.FormatFloat(bound, 'g', -1, 64), h.buckets[i])
+		}
+		fmt.Fprintf(b, "informant_feed_fetch_duration_seconds_bucket{feed=%s,le=\"+Inf\"} %d\n", name, h.count)
+		fmt.Fprintf(b, "informant_feed_fetch_duration_seconds_sum{feed=%s} %g\n", name, h.sum)
+		fmt.Fprintf(b, "informant_feed_fetch_duration_seconds_count{feed=%s} %d\n", name, h.count)
+	}
+
+	return b.Flush()
+}
+
+// WriteFile writes the metrics to path for the textfile collector. The file
+// is written to a temporary name and renamed, so a scrape never reads a
+// partial file.
+func WriteFile(path string, feeds []Feed) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+
+	if err := Write(file, feeds); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to set metrics file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+
+	return nil
+}
+
+// header writes the HELP and TYPE lines of a metric
+func header(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// labelEscaper escapes label values as the text format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// label quotes a label value
+func label(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
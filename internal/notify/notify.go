@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"informant/internal/config"
+	"informant/internal/feed"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Summary describes a batch of new unread items to notify about
+type Summary struct {
+	Title   string
+	Message string
+	Items   []feed.Item
+}
+
+// Notifier delivers a summary to a push notification service
+type Notifier interface {
+	Notify(summary Summary) error
+}
+
+// httpClient is used for all notification requests
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// New creates a Notifier for the given endpoint configuration
+func New(cfg config.Notification) (Notifier, error) {
+	switch cfg.Type {
+	case "ntfy":
+		return &ntfy{cfg: cfg}, nil
+	case "gotify":
+		return &gotify{cfg: cfg}, nil
+	case "webhook":
+		return newWebhook(cfg)
+	default:
+		return nil, fmt.Errorf("unknown notification type: %q", cfg.Type)
+	}
+}
+
+// Summarize builds a notification summary for new unread items
+func Summarize(items []feed.Item) Summary {
+	title := "1 new unread news item"
+	if len(items) != 1 {
+		title = fmt.Sprintf("%d new unread news items", len(items))
+	}
+
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString("• " + item.Title)
+		if item.FeedName != "" {
+			b.WriteString(" (" + item.FeedName + ")")
+		}
+		b.WriteString("\n")
+	}
+
+	return Summary{
+		Title:   title,
+		Message: strings.TrimSpace(b.String()),
+		Items:   items,
+	}
+}
+
+// ntfy publishes messages to an ntfy topic URL
+type ntfy struct {
+	cfg config.Notification
+}
+
+func (n *ntfy) Notify(summary Summary) error {
+	req, err := http.NewRequest(http.MethodPost, n.cfg.URL, strings.NewReader(summary.Message))
+	if err != nil {
+		return fmt.Errorf("failed to create ntfy request: %w", err)
+	}
+
+	req.Header.Set("Title", summary.Title)
+	req.Header.Set("Tags", "newspaper")
+	if n.cfg.Priority > 0 {
+		req.Header.Set("Priority", strconv.Itoa(n.cfg.Priority))
+	}
+	if n.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.cfg.Token)
+	}
+
+	return send(req)
+}
+
+// gotify posts messages to a Gotify server's message endpoint
+type gotify struct {
+	cfg config.Notification
+}
+
+func (g *gotify) Notify(summary Summary) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"title":    summary.Title,
+		"message":  summary.Message,
+		"priority": g.cfg.Priority,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal gotify message: %w", err)
+	}
+
+	url := strings.TrimRight(g.cfg.URL, "/") + "/message"
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create gotify request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", g.cfg.Token)
+
+	return send(req)
+}
+
+// send performs a notification request and checks the response status
+func send(req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification failed with HTTP status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
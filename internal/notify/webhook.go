@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"informant/internal/config"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// defaultWebhookTemplate is used when no payload template is configured
+const defaultWebhookTemplate = `{
+  "title": {{ json .Title }},
+  "message": {{ json .Message }},
+  "count": {{ len .Items }},
+  "items": [{{ range $i, $item := .Items }}{{ if $i }},{{ end }}
+    {"id": {{ json $item.ID }}, "title": {{ json $item.Title }}, "link": {{ json $item.Link }}, "feed": {{ json $item.FeedName }}, "published": {{ json $item.Published }}}{{ end }}
+  ]
+}`
+
+// webhook sends a templated JSON payload to an arbitrary HTTP endpoint
+type webhook struct {
+	cfg  config.Notification
+	tmpl *template.Template
+}
+
+func newWebhook(cfg config.Notification) (*webhook, error) {
+	text := cfg.Template
+	if text == "" {
+		text = defaultWebhookTemplate
+	}
+
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+
+	return &webhook{cfg: cfg, tmpl: tmpl}, nil
+}
+
+func (w *webhook) Notify(summary Summary) error {
+	var payload bytes.Buffer
+	if err := w.tmpl.Execute(&payload, summary); err != nil {
+		return fmt.Errorf("failed to render webhook payload: %w", err)
+	}
+
+	if !json.Valid(payload.Bytes()) {
+		return fmt.Errorf("webhook template did not produce valid JSON")
+	}
+
+	method := strings.ToUpper(w.cfg.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	req, err := http.NewRequest(method, w.cfg.URL, &payload)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+	if w.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.cfg.Token)
+	}
+
+	return send(req)
+}
@@ -0,0 +1,69 @@
+// Package platform tells the hosts informant runs on apart. System-wide
+// storage and the package manager hook only make sense on Linux hosts with a
+// supported package manager; elsewhere, such as on macOS, informant is a
+// per-user feed reader.
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Package managers informant can install a hook for
+const (
+	Pacman = "pacman"
+	Apt    = "apt"
+	Dnf    = "dnf"
+)
+
+// PackageManagers lists the supported package managers, in the order they
+// are detected in
+var PackageManagers = []string{Pacman, Apt, Dnf}
+
+// packageManagerConfigs are the paths whose presence identifies a host
+// managed by each package manager
+var packageManagerConfigs = map[string]string{
+	Pacman: "/etc/pacman.conf",
+	Apt:    "/etc/apt",
+	Dnf:    "/etc/dnf",
+}
+
+// ErrNoPacman is returned for pacman-specific operations on other hosts
+var ErrNoPacman = errors.New("pacman is not available on this system")
+
+// PackageManager returns the supported package manager the host is managed
+// by, empty if there is none
+func PackageManager() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+	for _, name := range PackageManagers {
+		if _, err := os.Stat(packageManagerConfigs[name]); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// HasPacman reports whether the host is managed by pacman, i.e. runs Arch
+// Linux or a derivative
+func HasPacman() bool {
+	return PackageManager() == Pacman
+}
+
+// UserCacheDir returns the directory for the per-user feed cache on hosts
+// where it does not live next to the read status, e.g. ~/Library/Caches on
+// macOS. It is empty on Linux, where the cache has always been kept in the
+// config directory.
+func UserCacheDir() string {
+	if runtime.GOOS == "linux" {
+		return ""
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "informant")
+}
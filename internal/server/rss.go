@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// rssDocument is the RSS 2.0 feed republished by /feed.xml
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description"`
+	Category    string  `xml:"category,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// handleFeed serves GET /feed.xml, all configured feeds merged into a single
+// RSS feed, optionally limited to unread items with ?unread=true. Items
+// published by several feeds under the same link appear once.
+func (s *Server) handleFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+
+	channel := rssChannel{
+		Title:         "informant",
+		Link:          "http://" + r.Host + "/feed.xml",
+		Description:   "News items from all feeds configured in informant",
+		LastBuildDate: time.Now().Format(time.RFC1123Z),
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range s.items() {
+		if unreadOnly && s.storage.IsRead(item.Key()) {
+			continue
+		}
+
+		key := item.Link
+		if key == "" {
+			key = item.Key()
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		channel.Items = append(channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Content,
+			Category:    item.FeedName,
+			GUID:        rssGUID{Value: item.Key()},
+			PubDate:     item.Published.Format(time.RFC1123Z),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	enc.Encode(rssDocument{Version: "2.0", Channel: channel})
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"encoding/json"
+	"informant/internal/feed"
+	"informant/internal/storage"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item is the JSON representation of a news item served by the API
+type Item struct {
+	Index     int       `json:"index"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content,omitempty"`
+	Link      string    `json:"link,omitempty"`
+	FeedName  string    `json:"feed_name,omitempty"`
+	Published time.Time `json:"published"`
+	Read      bool      `json:"read"`
+}
+
+// Server exposes news items and read status over a small REST API
+type Server struct {
+	load    func() []feed.Item
+	storage *storage.Storage
+	mux     *http.ServeMux
+}
+
+// New creates a Server. load is called for every request that needs the
+// current items, so it should be backed by the feed cache.
+func New(load func() []feed.Item, storage *storage.Storage) *Server {
+	s := &Server{
+		load:    load,
+		storage: storage,
+		mux:     http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/api/items", s.handleItems)
+	s.mux.HandleFunc("/api/items/", s.handleItem)
+	s.mux.HandleFunc("/api/unread-count", s.handleUnreadCount)
+	s.mux.HandleFunc("/feed.xml", s.handleFeed)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// items returns the current items sorted newest first, matching 'informant list'
+func (s *Server) items() []feed.Item {
+	items := s.load()
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published)
+	})
+	return items
+}
+
+// toAPIItem converts a feed item at the given list position
+func (s *Server) toAPIItem(index int, item feed.Item, withContent bool) Item {
+	apiItem := Item{
+		Index:     index + 1,
+		ID:        item.ID,
+		Title:     item.Title,
+		Link:      item.Link,
+		FeedName:  item.FeedName,
+		Published: item.Published,
+		Read:      s.storage.IsRead(item.Key()),
+	}
+	if withContent {
+		apiItem.Content = item.Content
+	}
+	return apiItem
+}
+
+// handleItems serves GET /api/items, optionally filtered with ?unread=true
+func (s *Server) handleItems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+
+	result := []Item{}
+	for i, item := range s.items() {
+		if unreadOnly && s.storage.IsRead(item.Key()) {
+			continue
+		}
+		result = append(result, s.toAPIItem(i, item, false))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleItem serves GET /api/items/{index} and POST /api/items/{index}/read|unread
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/items/"), "/"), "/")
+
+	items := s.items()
+	index, err := strconv.Atoi(parts[0])
+	if err != nil || index < 1 || index > len(items) {
+		writeError(w, http.StatusNotFound, "item not found")
+		return
+	}
+	item := items[index-1]
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.toAPIItem(index-1, item, true))
+
+	case len(parts) == 2 && r.Method == http.MethodPost && (parts[1] == "read" || parts[1] == "unread"):
+		if parts[1] == "read" {
+			err = s.storage.MarkAsRead(item.Key())
+		} else {
+			err = s.storage.MarkAsUnread(item.Key())
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, s.toAPIItem(index-1, item, false))
+
+	case len(parts) <= 2:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// handleUnreadCount serves GET /api/unread-count
+func (s *Server) handleUnreadCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	unread := 0
+	for _, item := range s.load() {
+		key := item.Key()
+		if !s.storage.IsRead(key) && !s.storage.IsSnoozed(key) && !s.storage.IsLater(key) {
+			unread++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"unread": unread})
+}
+
+// writeJSON writes v as a JSON response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
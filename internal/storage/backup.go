@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"informant/internal/feed"
+)
+
+// ExportStatus returns the read status, including stars, as JSON
+func (s *Storage) ExportStatus() ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	data, err := json.MarshalIndent(s.status, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal read status: %w", err)
+	}
+	return data, nil
+}
+
+// ExportArchive returns the offline item archive as JSON
+func (s *Storage) ExportArchive() ([]byte, error) {
+	s.archiveMutex.RLock()
+	defer s.archiveMutex.RUnlock()
+
+	data, err := json.Marshal(s.archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal item archive: %w", err)
+	}
+	return data, nil
+}
+
+// RestoreStatus replaces the read status with one exported by ExportStatus,
+// upgrading it if it was written by an older version
+func (s *Storage) RestoreStatus(data []byte) error {
+	var status ReadStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("failed to parse read status: %w", err)
+	}
+	if _, err := migrateStatus(&status); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The restored status replaces what is on disk, so it is not refreshed,
+	// but other processes must not write in between
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	*s.status = status
+
+	// Per-item files win over the status file on load, so bring them in line
+	if s.perItem {
+		for itemID, readAt := range s.status.ReadItems {
+			if err := s.writeItemFile(itemID, true, readAt); err != nil {
+				return err
+			}
+		}
+		for itemID, unreadAt := range s.status.UnreadItems {
+			if err := s.writeItemFile(itemID, false, unreadAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.save()
+}
+
+// RestoreArchive adds the items of an archive exported by ExportArchive to
+// the offline archive
+func (s *Storage) RestoreArchive(data []byte) error {
+	archive := Archive{Items: make(map[string]feed.Item)}
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return fmt.Errorf("failed to parse item archive: %w", err)
+	}
+
+	s.archiveMutex.Lock()
+	defer s.archiveMutex.Unlock()
+
+	for key, item := range archive.Items {
+		s.archive.Items[key] = item
+	}
+	return s.saveArchive()
+}
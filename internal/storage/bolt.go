@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"informant/internal/feed"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of the bolt database
+var (
+	statusBucket  = []byte("status")
+	cacheBucket   = []byte("cache")
+	archiveBucket = []byte("archive")
+)
+
+// statusKey is the key of the read status in the status bucket
+var statusKey = []byte("read-status")
+
+// boltOpenTimeout bounds how long to wait for another informant process
+// holding the database
+const boltOpenTimeout = 10 * time.Second
+
+// boltStore keeps the read status, feed cache and item archive in a single
+// bbolt database file. The database is opened for each operation rather than
+// for the lifetime of the process, because bbolt locks the file exclusively
+// and a long-running 'informant watch' would otherwise block the pacman hook.
+type boltStore struct {
+	path string
+	perm os.FileMode
+
+	// shared is set for the system-wide database
+	shared bool
+}
+
+// boltPathFor returns the database path next to the read status file
+func boltPathFor(filePath string, isSystemWide bool) string {
+	if isSystemWide {
+		return filepath.Join(filepath.Dir(filePath), "informant-go.db")
+	}
+	return filepath.Join(filepath.Dir(filePath), ".informant.db")
+}
+
+// update runs fn in a read-write transaction with all buckets created
+func (b *boltStore) update(fn func(tx *bolt.Tx) error) error {
+	db, err := bolt.Open(b.path, b.perm, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	// Let the storage group update the system-wide database, as with the
+	// status file
+	if b.shared {
+		if err := sharePath(b.path, b.perm); err != nil {
+			return err
+		}
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{statusBucket, cacheBucket, archiveBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", name, err)
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// view runs fn in a read-only transaction. Buckets may be missing when the
+// database has never been written to.
+func (b *boltStore) view(fn func(tx *bolt.Tx) error) error {
+	if _, err := os.Stat(b.path); err != nil {
+		return err
+	}
+
+	db, err := bolt.Open(b.path, b.perm, &bolt.Options{Timeout: boltOpenTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	return db.View(fn)
+}
+
+// loadStatus reads the read status into status. It returns an error
+// satisfying os.IsNotExist when nothing has been stored yet.
+func (b *boltStore) loadStatus(status *ReadStatus) error {
+	var data []byte
+	err := b.view(func(tx *bolt.Tx) error {
+		if bucket := tx.Bucket(statusBucket); bucket != nil {
+			// Values are only valid during the transaction
+			data = append([]byte(nil), bucket.Get(statusKey)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return os.ErrNotExist
+	}
+
+	return json.Unmarshal(data, status)
+}
+
+// saveStatus stores the read status
+func (b *boltStore) saveStatus(status *ReadStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal read status: %w", err)
+	}
+
+	return b.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(statusBucket).Put(statusKey, data)
+	})
+}
+
+// getCache returns the cache entry for a feed URL
+func (b *boltStore) getCache(url string) (CacheEntry, bool) {
+	var entry CacheEntry
+	found := false
+	err := b.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(cacheBucket)
+		if bucket == nil {
+			return nil
+		}
+		data := bucket.Get([]byte(url))
+		if data == nil {
+			return nil
+		}
+		found = json.Unmarshal(data, &entry) == nil
+		return nil
+	})
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	return entry, found
+}
+
+// setCache stores the cache entry for a feed URL
+func (b *boltStore) setCache(entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	return b.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(cacheBucket).Put([]byte(entry.URL), data)
+	})
+}
+
+// loadArchive reads every archived item
+func (b *boltStore) loadArchive(archive *Archive) error {
+	err := b.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(archiveBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			var item feed.Item
+			if err := json.Unmarshal(value, &item); err != nil {
+				return fmt.Errorf("failed to decode archived item %s: %w", key, err)
+			}
+			archive.Items[string(key)] = item
+			return nil
+		})
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// saveArchive stores every item of the archive in one transaction
+func (b *boltStore) saveArchive(archive *Archive) error {
+	return b.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(archiveBucket)
+		for key, item := range archive.Items {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal archived item: %w", err)
+			}
+			if err := bucket.Put([]byte(key), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateToBolt imports the read status and archive from the JSON files
+// into a new database, so switching backends keeps existing marks
+func (s *Storage) migrateToBolt() error {
+	if _, err := os.Stat(s.bolt.path); err == nil {
+		return nil
+	}
+
+	if data, err := os.ReadFile(s.filePath); err == nil {
+		var status ReadStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			return fmt.Errorf("failed to decode read status: %w", err)
+		}
+		if err := s.bolt.saveStatus(&status); err != nil {
+			return err
+		}
+	}
+
+	if data, err := os.ReadFile(s.archivePath); err == nil {
+		archive := &Archive{}
+		if err := json.Unmarshal(data, archive); err != nil {
+			return fmt.Errorf("failed to decode item archive: %w", err)
+		}
+		if err := s.bolt.saveArchive(archive); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// itemStatus is the content of a single per-item read status file. Unread
+// marks are kept as tombstones so the most recent change wins on merge.
+type itemStatus struct {
+	ID   string    `json:"id"`
+	Read bool      `json:"read"`
+	At   time.Time `json:"at"`
+}
+
+// itemDirFor returns the per-item status directory that belongs to a status file
+func itemDirFor(filePath string) string {
+	return strings.TrimSuffix(filePath, filepath.Ext(filePath)) + ".d"
+}
+
+// itemFilePath returns the status file path for an item
+func (s *Storage) itemFilePath(itemID string) string {
+	hash := sha256.Sum256([]byte(itemID))
+	return filepath.Join(s.itemDir, fmt.Sprintf("%x.json", hash))
+}
+
+// loadItemFiles replaces the in-memory read marks with the per-item files.
+// Every JSON file in the directory is considered, including conflict copies
+// created by file syncing tools, and the latest mark for each item wins.
+// When the directory does not exist yet, the existing marks are migrated.
+func (s *Storage) loadItemFiles() error {
+	entries, err := os.ReadDir(s.itemDir)
+	if os.IsNotExist(err) {
+		return s.migrateToItemFiles()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read item status directory: %w", err)
+	}
+
+	latest := make(map[string]itemStatus)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.itemDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		var status itemStatus
+		if err := json.Unmarshal(data, &status); err != nil || status.ID == "" {
+			continue
+		}
+
+		if current, exists := latest[status.ID]; !exists || status.At.After(current.At) {
+			latest[status.ID] = status
+		}
+	}
+
+	s.status.ReadItems = make(map[string]time.Time)
+	for id, status := range latest {
+		if status.Read {
+			s.status.ReadItems[id] = status.At
+		}
+	}
+
+	return nil
+}
+
+// migrateToItemFiles writes a per-item file for every existing read mark
+func (s *Storage) migrateToItemFiles() error {
+	if err := s.ensureItemDir(); err != nil {
+		return err
+	}
+
+	for id, readTime := range s.status.ReadItems {
+		if err := s.writeItemFile(id, true, readTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureItemDir creates the per-item status directory
+func (s *Storage) ensureItemDir() error {
+	if exists(s.itemDir) {
+		return nil
+	}
+	if err := os.MkdirAll(s.itemDir, 0755); err != nil {
+		return fmt.Errorf("failed to create item status directory: %w", err)
+	}
+
+	// Allow the storage group to add marks to system-wide storage
+	return s.share(s.itemDir, systemDirPerm)
+}
+
+// writeItemFile records the read state of a single item. The file is
+// written to a temporary name and renamed so syncing tools never pick up
+// a partial write.
+func (s *Storage) writeItemFile(itemID string, read bool, at time.Time) error {
+	if err := s.ensureItemDir(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(itemStatus{ID: itemID, Read: read, At: at})
+	if err != nil {
+		return fmt.Errorf("failed to marshal item status: %w", err)
+	}
+
+	path := s.itemFilePath(itemID)
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, s.filePerm()); err != nil {
+		return fmt.Errorf("failed to write item status: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write item status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package storage
+
+import "time"
+
+// IsLater reports whether an item is in the read-later queue
+func (s *Storage) IsLater(itemID string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.status.LaterItems[itemID]
+	return exists
+}
+
+// SetLater adds an item to the read-later queue or removes it
+func (s *Storage) SetLater(itemID string, later bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !later {
+		delete(s.status.LaterItems, itemID)
+		return s.save()
+	}
+
+	if s.status.LaterItems == nil {
+		s.status.LaterItems = make(map[string]time.Time)
+	}
+	s.status.LaterItems[itemID] = time.Now()
+	return s.save()
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// lockPath returns the path of the file used to serialize updates between
+// informant processes
+func (s *Storage) lockPath() string {
+	return lockPathFor(s.filePath)
+}
+
+// lockPathFor returns the lock file path for a read status file
+func lockPathFor(filePath string) string {
+	return filePath + ".lock"
+}
+
+// fetchLockPathFor returns the path of the file used to keep informant
+// processes from fetching the feeds at the same time
+func fetchLockPathFor(filePath string) string {
+	return filePath + ".fetch.lock"
+}
+
+// lockPoll is how often a lock held by another process is retried
+const lockPoll = 100 * time.Millisecond
+
+// flock takes an exclusive lock on file. A free lock is taken even when ctx
+// is done; one held by another process is retried until ctx is done. It
+// reports whether it had to wait.
+func flock(ctx context.Context, file *os.File) (bool, error) {
+	waited := false
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return waited, nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			return waited, err
+		}
+
+		// Polled rather than blocking, so that ctx can end the wait
+		waited = true
+		select {
+		case <-ctx.Done():
+			return waited, ctx.Err()
+		case <-time.After(lockPoll):
+		}
+	}
+}
+
+// LockFetch takes the lock that keeps informant processes, such as parallel
+// pacman hooks or a hook and watch, from fetching the feeds and updating
+// their state at the same time. When another process holds it, LockFetch
+// waits until it is released or ctx is done, and reports that it waited: the
+// feeds that process fetched are then in the cache. The returned function
+// releases the lock, and may be called more than once.
+func (s *Storage) LockFetch(ctx context.Context) (func(), bool, error) {
+	file, err := os.OpenFile(fetchLockPathFor(s.filePath), os.O_CREATE|os.O_RDONLY, s.filePerm())
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open fetch lock file: %w", err)
+	}
+
+	waited, err := flock(ctx, file)
+	if err != nil {
+		file.Close()
+		if waited {
+			return nil, waited, fmt.Errorf("gave up waiting for another informant run: %w", err)
+		}
+		return nil, waited, fmt.Errorf("failed to lock feed fetching: %w", err)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+			file.Close()
+		})
+	}, waited, nil
+}
+
+// lockAndRefresh takes the inter-process lock and merges the read status on
+// disk into memory, so that a long-lived process such as the TUI does not
+// overwrite changes made meanwhile by another one, such as the pacman hook.
+// The caller must hold s.mutex, and call the returned function once the
+// change has been saved. Nothing slow, such as a remote sync, may run while
+// the lock is held: the pacman hook waits for it.
+func (s *Storage) lockAndRefresh() (func(), error) {
+	// Locking only needs read access, so group members can lock the
+	// system-wide file root created
+	file, err := os.OpenFile(s.lockPath(), os.O_CREATE|os.O_RDONLY, s.filePerm())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if _, err := flock(s.context(), file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock read status: %w", err)
+	}
+	unlock := func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}
+
+	if err := s.refresh(); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
+
+// refresh merges the read status on disk into memory. Read and unread marks
+// are merged so the most recent mark for each item wins, in the per-item
+// layout by reading the item files again, and notifications are combined.
+// Stars and feed status are taken from disk: every change is saved right
+// away, so the disk copy holds the changes made in this process as well as
+// those made by others.
+func (s *Storage) refresh() error {
+	disk, _, err := s.readStatus()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to reload read status: %w", err)
+	}
+
+	// Item files are the source of truth for marks in the per-item layout,
+	// and each is written on its own
+	if !s.perItem {
+		if _, err := s.mergeStatus(disk); err != nil {
+			return err
+		}
+	} else {
+		s.adoptPruneCutoff(disk)
+		// Pick up the marks other processes and file syncing added
+		if exists(s.itemDir) {
+			if err := s.loadItemFiles(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.status.NotifiedItems == nil {
+		s.status.NotifiedItems = make(map[string]time.Time)
+	}
+	for itemID, notifiedAt := range disk.NotifiedItems {
+		if notifiedAt.Before(s.status.PrunedBefore) {
+			continue
+		}
+		if _, ok := s.status.NotifiedItems[itemID]; !ok {
+			s.status.NotifiedItems[itemID] = notifiedAt
+		}
+	}
+
+	s.status.StarredItems = disk.StarredItems
+	s.status.SnoozedItems = disk.SnoozedItems
+	s.status.LaterItems = disk.LaterItems
+	s.status.Feeds = disk.Feeds
+	s.status.InitialReadPending = disk.InitialReadPending
+	s.status.InitialReadFeeds = disk.InitialReadFeeds
+
+	return nil
+}
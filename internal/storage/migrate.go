@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"informant/internal/logging"
+)
+
+// migration upgrades a read status by one schema version
+type migration struct {
+	description string
+	apply       func(status *ReadStatus) error
+}
+
+// migrations upgrade read status written by older versions of informant.
+// migrations[i] upgrades a status from version i to version i+1. Files
+// written before versioning was introduced have version 0. New migrations
+// are appended here; existing ones must never change.
+var migrations = []migration{
+	{
+		description: "add maps missing from files written before versioning",
+		apply: func(status *ReadStatus) error {
+			if status.ReadItems == nil {
+				status.ReadItems = make(map[string]time.Time)
+			}
+			if status.NotifiedItems == nil {
+				status.NotifiedItems = make(map[string]time.Time)
+			}
+			return nil
+		},
+	},
+}
+
+// statusVersion is the read status schema version written by this build
+var statusVersion = len(migrations)
+
+// migrateStatus upgrades a read status to the current schema version in
+// place and reports whether it was changed. A status written by a newer
+// informant is rejected rather than risk losing what it stores.
+func migrateStatus(status *ReadStatus) (bool, error) {
+	if status.Version > statusVersion {
+		return false, fmt.Errorf("read status has version %d, but this informant only supports up to version %d; please upgrade", status.Version, statusVersion)
+	}
+
+	migrated := false
+	for status.Version < statusVersion {
+		m := migrations[status.Version]
+		if err := m.apply(status); err != nil {
+			return migrated, fmt.Errorf("failed to migrate read status to version %d (%s): %w", status.Version+1, m.description, err)
+		}
+		status.Version++
+		migrated = true
+		logging.Infof("Migrated read status to version %d: %s", status.Version, m.description)
+	}
+
+	return migrated, nil
+}
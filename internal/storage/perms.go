@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"informant/internal/config"
+	"informant/internal/logging"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Modes of the storage files. System-wide storage is writable by the
+// storage group rather than by every user, and its directories are setgid so
+// that files created by group members belong to the group too.
+const (
+	userFilePerm   os.FileMode = 0644
+	systemFilePerm os.FileMode = 0664
+	systemDirPerm              = 0775 | os.ModeSetgid
+)
+
+// filePerm returns the mode of files written by this storage
+func (s *Storage) filePerm() os.FileMode {
+	if s.isSystemWide {
+		return systemFilePerm
+	}
+	return userFilePerm
+}
+
+var (
+	storageGID     int
+	storageGIDErr  error
+	storageGIDOnce sync.Once
+
+	// missingGroupWarning reports a missing storage group only once
+	missingGroupWarning sync.Once
+)
+
+// LookupStorageGroup returns the ID of the configured storage group
+func LookupStorageGroup() (int, error) {
+	storageGIDOnce.Do(func() {
+		name := config.GetStorageGroup()
+		group, err := user.LookupGroup(name)
+		if err != nil {
+			storageGIDErr = fmt.Errorf("failed to look up storage group %s: %w", name, err)
+			return
+		}
+		storageGID, storageGIDErr = strconv.Atoi(group.Gid)
+	})
+	return storageGID, storageGIDErr
+}
+
+// share gives the storage group access to a system-wide file or directory
+// the storage has just created. Existing paths are left as they are: only
+// 'informant install' changes them, once the group exists.
+func (s *Storage) share(path string, perm os.FileMode) error {
+	if !s.isSystemWide {
+		return nil
+	}
+
+	if os.Geteuid() == 0 {
+		if _, err := LookupStorageGroup(); err != nil {
+			// Keep the storage usable by root; other users fall back to
+			// per-user storage until the group is set up
+			missingGroupWarning.Do(func() {
+				logging.Warnf("%v; run 'informant install' to share system-wide storage", err)
+			})
+			return nil
+		}
+	}
+
+	return sharePath(path, perm)
+}
+
+// sharePath sets the group and mode of a system-wide path. Root hands the
+// group ownership of it; group members can only fix the mode of files they
+// created themselves, which lose group write through the umask.
+func sharePath(path string, perm os.FileMode) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if os.Geteuid() != 0 {
+		if info.Mode()&(os.ModePerm|os.ModeSetgid) != perm {
+			// Fails for files owned by others, which root already set up
+			os.Chmod(path, perm)
+		}
+		return nil
+	}
+
+	gid, err := LookupStorageGroup()
+	if err != nil {
+		return err
+	}
+	if err := os.Chown(path, -1, gid); err != nil {
+		return fmt.Errorf("failed to set group of %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, perm); err != nil {
+		return fmt.Errorf("failed to set permissions of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// exists reports whether path exists, so that only created paths are shared
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ShareSystemStorage creates the system-wide read status if needed and hands
+// every existing storage file to the storage group, replacing the
+// world-writable modes of earlier versions. It must be run as root.
+func (s *Storage) ShareSystemStorage() error {
+	if !s.isSystemWide {
+		return fmt.Errorf("storage is not system-wide")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Group members cannot create files in /var/lib, so create them upfront
+	if s.bolt != nil {
+		if err := s.bolt.update(func(tx *bolt.Tx) error { return nil }); err != nil {
+			return err
+		}
+	} else {
+		if _, err := os.Stat(s.filePath); os.IsNotExist(err) {
+			if err := s.writeStatus(); err != nil {
+				return err
+			}
+		}
+		if _, err := os.Stat(s.archivePath); os.IsNotExist(err) {
+			s.archiveMutex.Lock()
+			err := s.saveArchive()
+			s.archiveMutex.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if s.perItem {
+		if err := s.ensureItemDir(); err != nil {
+			return err
+		}
+	}
+
+	paths := []string{s.filePath, s.lockPath(), fetchLockPathFor(s.filePath), s.archivePath, s.itemDir}
+	if s.bolt != nil {
+		paths = append(paths, s.bolt.path)
+	}
+	// A configured cache directory may hold more than informant's files
+	if s.cacheDir == defaultSystemCacheDir {
+		paths = append(paths, s.cacheDir)
+	}
+	for _, path := range paths {
+		perm := systemFilePerm
+		if path == s.cacheDir || path == s.itemDir {
+			perm = systemDirPerm
+		}
+		if err := sharePath(path, perm); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	// Files in shared directories are shared one by one, leaving alone
+	// whatever else is in them
+	files := cacheEntries(s.cacheDir)
+	if entries, err := os.ReadDir(s.itemDir); err == nil {
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".json") {
+				files = append(files, filepath.Join(s.itemDir, entry.Name()))
+			}
+		}
+	}
+	for _, path := range files {
+		if info, err := os.Lstat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if err := sharePath(path, systemFilePerm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"informant/internal/feed"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// PruneResult counts the entries removed, or that would be removed in a dry
+// run, by Prune
+type PruneResult struct {
+	ReadMarks     int
+	UnreadMarks   int
+	NotifiedMarks int
+	ArchivedItems int
+	CacheEntries  int
+}
+
+// Prune removes read, unread and notification marks made before maxAge ago,
+// archived items published before then and expired feed cache entries.
+// Starred and read-later items are kept in the archive. With dryRun nothing is changed and
+// the result only reports what would be removed.
+func (s *Storage) Prune(maxAge time.Duration, dryRun bool) (PruneResult, error) {
+	var result PruneResult
+	cutoff := time.Now().Add(-maxAge)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	for itemID, readTime := range s.status.ReadItems {
+		if readTime.Before(cutoff) {
+			result.ReadMarks++
+			if !dryRun {
+				delete(s.status.ReadItems, itemID)
+				if s.perItem {
+					os.Remove(s.itemFilePath(itemID))
+				}
+			}
+		}
+	}
+
+	for itemID, unreadTime := range s.status.UnreadItems {
+		if unreadTime.Before(cutoff) {
+			result.UnreadMarks++
+			if !dryRun {
+				delete(s.status.UnreadItems, itemID)
+				if s.perItem {
+					os.Remove(s.itemFilePath(itemID))
+				}
+			}
+		}
+	}
+
+	for itemID, notifiedTime := range s.status.NotifiedItems {
+		if notifiedTime.Before(cutoff) {
+			result.NotifiedMarks++
+			if !dryRun {
+				delete(s.status.NotifiedItems, itemID)
+			}
+		}
+	}
+
+	// Expired snoozes have no effect any more
+	if !dryRun {
+		if cutoff.After(s.status.PrunedBefore) {
+			s.status.PrunedBefore = cutoff
+		}
+
+		for itemID, until := range s.status.SnoozedItems {
+			if until.Before(time.Now()) {
+				delete(s.status.SnoozedItems, itemID)
+			}
+		}
+	}
+
+	archived, err := s.pruneArchive(cutoff, dryRun)
+	if err != nil {
+		return result, err
+	}
+	result.ArchivedItems = archived
+
+	// Entries may be kept for as long as the server allows
+	cacheAge := feed.CacheTTL
+	if feed.CacheMaxTTL > cacheAge {
+		cacheAge = feed.CacheMaxTTL
+	}
+	cached, err := s.pruneCache(time.Now().Add(-cacheAge), dryRun)
+	if err != nil {
+		return result, err
+	}
+	result.CacheEntries = cached
+
+	if dryRun {
+		return result, nil
+	}
+	return result, s.save()
+}
+
+// adoptPruneCutoff takes over the prune cutoff of another copy of the read
+// status when it is later, dropping the marks that prune removed there
+func (s *Storage) adoptPruneCutoff(other *ReadStatus) {
+	if !other.PrunedBefore.After(s.status.PrunedBefore) {
+		return
+	}
+	s.status.PrunedBefore = other.PrunedBefore
+
+	for _, marks := range []map[string]time.Time{s.status.ReadItems, s.status.UnreadItems} {
+		for itemID, markTime := range marks {
+			if markTime.Before(s.status.PrunedBefore) {
+				delete(marks, itemID)
+				if s.perItem {
+					os.Remove(s.itemFilePath(itemID))
+				}
+			}
+		}
+	}
+	for itemID, notifiedTime := range s.status.NotifiedItems {
+		if notifiedTime.Before(s.status.PrunedBefore) {
+			delete(s.status.NotifiedItems, itemID)
+		}
+	}
+}
+
+// pruneArchive removes archived items published before cutoff, except those
+// starred or queued to read later. The caller must hold s.mutex.
+func (s *Storage) pruneArchive(cutoff time.Time, dryRun bool) (int, error) {
+	s.archiveMutex.Lock()
+	defer s.archiveMutex.Unlock()
+
+	var keys []string
+	for key, item := range s.archive.Items {
+		if _, starred := s.status.StarredItems[key]; starred {
+			continue
+		}
+		if _, later := s.status.LaterItems[key]; later {
+			continue
+		}
+		if item.Published.Before(cutoff) {
+			keys = append(keys, key)
+		}
+	}
+
+	if dryRun || len(keys) == 0 {
+		return len(keys), nil
+	}
+
+	for _, key := range keys {
+		delete(s.archive.Items, key)
+	}
+
+	// The bolt backend only adds items when saving the archive
+	if s.bolt != nil {
+		return len(keys), s.bolt.deleteArchived(keys)
+	}
+	return len(keys), s.saveArchive()
+}
+
+// pruneCache removes feed cache entries stored before cutoff
+func (s *Storage) pruneCache(cutoff time.Time, dryRun bool) (int, error) {
+	if s.bolt != nil {
+		return s.bolt.pruneCache(cutoff, dryRun)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(s.cacheDir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		// Unreadable entries are never used, so they go as well
+		var entry CacheEntry
+		if err := json.Unmarshal(data, &entry); err == nil && !entry.Timestamp.Before(cutoff) {
+			continue
+		}
+
+		removed++
+		if !dryRun {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return removed, err
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// deleteArchived removes items from the archive bucket
+func (b *boltStore) deleteArchived(keys []string) error {
+	return b.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(archiveBucket)
+		for _, key := range keys {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// pruneCache removes cache entries stored before cutoff
+func (b *boltStore) pruneCache(cutoff time.Time, dryRun bool) (int, error) {
+	var keys [][]byte
+	collect := func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(cacheBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			var entry CacheEntry
+			if err := json.Unmarshal(value, &entry); err != nil || entry.Timestamp.Before(cutoff) {
+				keys = append(keys, append([]byte(nil), key...))
+			}
+			return nil
+		})
+	}
+
+	if dryRun {
+		err := b.view(collect)
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return len(keys), err
+	}
+
+	// Keys are collected first since buckets must not change during ForEach
+	err := b.update(func(tx *bolt.Tx) error {
+		if err := collect(tx); err != nil {
+			return err
+		}
+		bucket := tx.Bucket(cacheBucket)
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return len(keys), err
+}
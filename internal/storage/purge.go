@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"informant/internal/config"
+)
+
+// stateFiles returns every file and directory derived from a read status
+// file: the file itself, its locks, per-item directory and database
+func stateFiles(filePath, boltPath string) []string {
+	return []string{filePath, lockPathFor(filePath), fetchLockPathFor(filePath), itemDirFor(filePath), boltPath}
+}
+
+// StatePaths returns the existing read status, archive and cache files of the
+// system-wide storage and of the per-user storage in each of userDirs, for
+// removing all of informant's state
+func StatePaths(userDirs []string) []string {
+	candidates := stateFiles(systemFilePath, boltPathFor(systemFilePath, true))
+	candidates = append(candidates, systemArchivePath, defaultSystemCacheDir)
+	if dir := config.GetCacheDir(); dir != "" {
+		// A configured directory may hold other files, so only informant's
+		// own entries go
+		candidates = append(candidates, cacheEntries(dir)...)
+	}
+
+	for _, dir := range userDirs {
+		filePath := filepath.Join(dir, userFileName)
+		candidates = append(candidates, stateFiles(filePath, boltPathFor(filePath, false))...)
+		candidates = append(candidates, filepath.Join(dir, userArchiveName), filepath.Join(dir, userCacheName))
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, path := range candidates {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		if _, err := os.Lstat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// cacheEntries returns the feed cache entries in dir, named after the MD5
+// hash of their URL by getCacheFilePath
+func cacheEntries(dir string) []string {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil
+	}
+
+	var entries []string
+	for _, path := range paths {
+		hash := strings.TrimSuffix(filepath.Base(path), ".json")
+		if _, err := hex.DecodeString(hash); err == nil && len(hash) == 2*md5.Size {
+			entries = append(entries, path)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"informant/internal/config"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// s3Remote stores the read status as an object in an S3-compatible bucket.
+// Requests use path-style addressing and are signed with AWS Signature V4.
+type s3Remote struct {
+	cfg *config.Sync
+}
+
+func (r *s3Remote) Pull(ctx context.Context) ([]byte, bool, error) {
+	req, err := r.newRequest(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return doPull(req)
+}
+
+func (r *s3Remote) Push(ctx context.Context, data []byte) error {
+	req, err := r.newRequest(ctx, http.MethodPut, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doPush(req)
+}
+
+// newRequest creates a signed request for the configured object
+func (r *s3Remote) newRequest(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	objectURL := strings.TrimRight(r.cfg.URL, "/") + "/" + r.cfg.Bucket + "/" + strings.TrimLeft(r.cfg.Key, "/")
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytesReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	r.sign(req, body, time.Now().UTC())
+	return req, nil
+}
+
+// sign adds AWS Signature Version 4 headers to a request
+func (r *s3Remote) sign(req *http.Request, body []byte, now time.Time) {
+	region := r.cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+r.cfg.SecretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		r.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalURI URI-encodes each segment of the request path
+func canonicalURI(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,43 @@
+package storage
+
+import "time"
+
+// IsSnoozed reports whether an item is snoozed, i.e. left out of check and
+// unread counts until its snooze expires
+func (s *Storage) IsSnoozed(itemID string) bool {
+	until, ok := s.SnoozedUntil(itemID)
+	return ok && time.Now().Before(until)
+}
+
+// SnoozedUntil returns when the snooze of an item expires, if it has one
+func (s *Storage) SnoozedUntil(itemID string) (time.Time, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	until, ok := s.status.SnoozedItems[itemID]
+	return until, ok
+}
+
+// Snooze hides an item from check and unread counts until the given time.
+// A zero time removes the snooze.
+func (s *Storage) Snooze(itemID string, until time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if until.IsZero() {
+		delete(s.status.SnoozedItems, itemID)
+		return s.save()
+	}
+
+	if s.status.SnoozedItems == nil {
+		s.status.SnoozedItems = make(map[string]time.Time)
+	}
+	s.status.SnoozedItems[itemID] = until
+	return s.save()
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"bufio"
+	"context"
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
@@ -9,15 +10,57 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"informant/internal/config"
+	"informant/internal/feed"
+	"informant/internal/i18n"
+	"informant/internal/logging"
+	"informant/internal/platform"
 )
 
 // ReadStatus represents the read status of news items
 type ReadStatus struct {
-	ReadItems map[string]time.Time `json:"read_items"`
-	LastCheck time.Time            `json:"last_check"`
+	// Version is the schema version, see migrations
+	Version int `json:"version"`
+
+	ReadItems     map[string]time.Time  `json:"read_items"`
+	UnreadItems   map[string]time.Time  `json:"unread_items,omitempty"`
+	NotifiedItems map[string]time.Time  `json:"notified_items,omitempty"`
+	StarredItems  map[string]time.Time  `json:"starred_items,omitempty"`
+	SnoozedItems  map[string]time.Time  `json:"snoozed_items,omitempty"`
+	LaterItems    map[string]time.Time  `json:"later_items,omitempty"`
+	Feeds         map[string]FeedStatus `json:"feeds,omitempty"`
+	LastCheck     time.Time             `json:"last_check"`
+
+	// InitialReadPending is set for a new read status until the existing
+	// items of every feed have been marked read, see MarkInitialRead
+	InitialReadPending bool `json:"initial_read_pending,omitempty"`
+	// InitialReadFeeds are the URLs of the feeds whose existing items were
+	// marked read while InitialReadPending is set
+	InitialReadFeeds map[string]time.Time `json:"initial_read_feeds,omitempty"`
+
+	// PrunedBefore is the cutoff of the latest prune. Marks made before it
+	// were removed, and are not merged back from other copies.
+	PrunedBefore time.Time `json:"pruned_before,omitempty"`
+}
+
+// FeedStatus records the outcome of the most recent fetches of a feed
+type FeedStatus struct {
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitempty"`
+	ItemCount   int       `json:"item_count"`
+}
+
+// FeedFetch is the outcome of fetching one feed
+type FeedFetch struct {
+	URL   string
+	Items int
+	Err   error
+	// Cached is set when the items came from the cache without a request
+	Cached bool
 }
 
 // CacheEntry represents a cached RSS feed
@@ -25,6 +68,23 @@ type CacheEntry struct {
 	Data      []byte    `json:"data"`
 	Timestamp time.Time `json:"timestamp"`
 	URL       string    `json:"url"`
+	// Expires is when the server said the data goes stale, if it did
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// fresh reports whether the entry may still be used: until the expiry the
+// server gave, or for maxAge after it was stored
+func (e CacheEntry) fresh(maxAge time.Duration) bool {
+	if !e.Expires.IsZero() {
+		return time.Now().Before(e.Expires)
+	}
+	return time.Since(e.Timestamp) <= maxAge
+}
+
+// Archive holds every item seen in a feed, keyed by item ID, so items stay
+// available after they drop out of the upstream feed
+type Archive struct {
+	Items map[string]feed.Item `json:"items"`
 }
 
 // Storage handles persistent storage of read status
@@ -34,12 +94,46 @@ type Storage struct {
 	mutex        sync.RWMutex
 	isSystemWide bool
 	cacheDir     string
+	archivePath  string
+	archive      *Archive
+	archiveMutex sync.RWMutex
+	perItem      bool
+	itemDir      string
+
+	// bolt is set when the bolt backend replaces the JSON files
+	bolt *boltStore
+
+	remote          Remote
+	autoSyncEnabled bool
+	// changed is set by every save, so that auto-sync runs once per command
+	// rather than for every change
+	changed bool
+
+	// ctx bounds waiting for the lock and remote sync requests
+	ctx context.Context
+}
+
+// SetContext bounds the waits of the storage from now on: for the lock held
+// by other processes, and for the remote when syncing. Changes that need no
+// waiting are still saved after ctx is done.
+func (s *Storage) SetContext(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ctx = ctx
+}
+
+// context returns the context set with SetContext, if any
+func (s *Storage) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
 
 // showStorageFallbackWarning displays a warning about falling back to per-user storage
 func showStorageFallbackWarning() {
-	fmt.Println("Warning: Cannot write to system-wide storage (/var/lib/informant-go.dat)")
-	fmt.Println("Falling back to per-user storage. This means read status won't be shared between users.")
+	fmt.Fprintln(os.Stderr, i18n.T("Warning: Cannot write to system-wide storage (/var/lib/informant-go.dat)"))
+	fmt.Fprintln(os.Stderr, i18n.T("Falling back to per-user storage. This means read status won't be shared between users."))
 }
 
 // New creates a new Storage instance
@@ -47,11 +141,27 @@ func New() (*Storage, error) {
 	return NewWithConfirmation(true)
 }
 
+// System-wide storage locations
+const (
+	systemFilePath        = "/var/lib/informant-go.dat"
+	systemArchivePath     = "/var/lib/informant-go-archive.dat"
+	defaultSystemCacheDir = "/var/cache/informant"
+)
+
+// Per-user storage file names, kept in the config directory
+const (
+	userFileName    = ".informant_read_status.json"
+	userArchiveName = ".informant_archive.json"
+	userCacheName   = ".informant_cache"
+)
+
 // NewWithConfirmation creates a new Storage instance with optional confirmation prompts
 func NewWithConfirmation(requireConfirmation bool) (*Storage, error) {
 	// Try system-wide storage first
-	systemFilePath := "/var/lib/informant-go.dat"
-	systemCacheDir := "/var/cache/informant"
+	systemCacheDir := defaultSystemCacheDir
+	if dir := config.GetCacheDir(); dir != "" {
+		systemCacheDir = dir
+	}
 
 	// Check if we're running as root
 	isRoot := os.Geteuid() == 0
@@ -59,7 +169,22 @@ func NewWithConfirmation(requireConfirmation bool) (*Storage, error) {
 	var filePath, cacheDir string
 	var isSystemWide bool
 
-	if isRoot {
+	if path := config.GetStoragePath(); path != "" {
+		// A configured path is used as-is, without sharing or fallback
+		var err error
+		filePath, cacheDir, err = getCustomStoragePaths(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare storage path: %w", err)
+		}
+	} else if !systemStorageAvailable(isRoot) {
+		// Without a package manager hook there is nothing to share the read
+		// status with
+		var err error
+		filePath, cacheDir, err = getUserStoragePaths()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user storage paths: %w", err)
+		}
+	} else if isRoot {
 		// Running as root - create system directories with proper permissions
 		if err := createSystemDirectories(systemFilePath, systemCacheDir); err != nil {
 			return nil, fmt.Errorf("failed to create system directories: %w", err)
@@ -69,7 +194,7 @@ func NewWithConfirmation(requireConfirmation bool) (*Storage, error) {
 		isSystemWide = true
 	} else {
 		// Try to use system-wide storage
-		if canUseSystemStorage(systemFilePath, systemCacheDir) {
+		if canUseSystemStorage(systemFilePath, systemArchivePath, systemCacheDir) {
 			filePath = systemFilePath
 			cacheDir = systemCacheDir
 			isSystemWide = true
@@ -93,27 +218,95 @@ func NewWithConfirmation(requireConfirmation bool) (*Storage, error) {
 		}
 	}
 
+	archivePath := filepath.Join(filepath.Dir(filePath), userArchiveName)
+	if isSystemWide {
+		archivePath = systemArchivePath
+	} else if config.GetStoragePath() != "" {
+		archivePath = siblingPath(filePath, "-archive", filepath.Ext(filePath))
+	}
+
 	storage := &Storage{
 		filePath:     filePath,
 		cacheDir:     cacheDir,
 		isSystemWide: isSystemWide,
+		archivePath:  archivePath,
+		perItem:      config.GetReadStatusLayout() == config.ReadStatusLayoutPerItem,
+		itemDir:      itemDirFor(filePath),
 		status: &ReadStatus{
-			ReadItems: make(map[string]time.Time),
-			LastCheck: time.Now(),
+			Version:       statusVersion,
+			ReadItems:     make(map[string]time.Time),
+			NotifiedItems: make(map[string]time.Time),
+			LastCheck:     time.Now(),
 		},
 	}
 
+	if config.GetStorageBackend() == config.StorageBackendBolt {
+		boltPath := boltPathFor(filePath, isSystemWide)
+		if config.GetStoragePath() != "" {
+			boltPath = siblingPath(filePath, "", ".db")
+		}
+		storage.bolt = &boltStore{
+			path:   boltPath,
+			perm:   storage.filePerm(),
+			shared: isSystemWide,
+		}
+		if err := storage.migrateToBolt(); err != nil {
+			return nil, fmt.Errorf("failed to migrate to database: %w", err)
+		}
+	}
+
 	// Load existing data if available
 	if err := storage.load(); err != nil {
 		// If file doesn't exist, that's okay - we'll create it on first save
 		if !os.IsNotExist(err) {
 			return nil, fmt.Errorf("failed to load read status: %w", err)
 		}
+		storage.status.InitialReadPending = true
+	}
+
+	if storage.perItem {
+		if err := storage.loadItemFiles(); err != nil {
+			return nil, fmt.Errorf("failed to load read status: %w", err)
+		}
+		if len(storage.status.ReadItems) > 0 {
+			storage.status.InitialReadPending = false
+		}
+	}
+
+	syncCfg, err := config.GetSync()
+	if err != nil {
+		return nil, err
+	}
+	if syncCfg != nil {
+		if storage.remote, err = newRemote(syncCfg); err != nil {
+			return nil, err
+		}
+		storage.autoSyncEnabled = syncCfg.Auto
+	}
+
+	if err := storage.loadArchive(); err != nil {
+		return nil, fmt.Errorf("failed to load item archive: %w", err)
 	}
 
 	return storage, nil
 }
 
+// systemStorageAvailable reports whether system-wide storage is tried. It is
+// on pacman hosts. With another supported package manager, it is used once
+// root created it, by installing the hook or running check from it.
+func systemStorageAvailable(isRoot bool) bool {
+	switch {
+	case platform.HasPacman():
+		return true
+	case platform.PackageManager() == "":
+		return false
+	case isRoot:
+		return true
+	}
+	_, err := os.Stat(lockPathFor(systemFilePath))
+	return err == nil
+}
+
 // createSystemDirectories creates system directories with proper permissions
 func createSystemDirectories(filePath, cacheDir string) error {
 	// Create /var/lib directory if it doesn't exist
@@ -123,34 +316,56 @@ func createSystemDirectories(filePath, cacheDir string) error {
 	}
 
 	// Create cache directory
+	created := !exists(cacheDir)
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	// Set permissions on cache directory so all users can write (only if needed)
-	if info, err := os.Stat(cacheDir); err == nil {
-		if info.Mode().Perm() != 0777 {
-			if err := os.Chmod(cacheDir, 0777); err != nil {
-				return fmt.Errorf("failed to set cache directory permissions: %w", err)
-			}
+	// Let the storage group write to the cache directory, unless it is a
+	// configured one that was there before
+	if created || cacheDir == defaultSystemCacheDir {
+		if err := sharePath(cacheDir, systemDirPerm); err != nil {
+			return fmt.Errorf("failed to set cache directory permissions: %w", err)
 		}
 	}
 
+	// Users cannot create the lock file in /var/lib themselves
+	lockPath := lockPathFor(filePath)
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDONLY, systemFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create lock file: %w", err)
+	}
+	file.Close()
+	if err := sharePath(lockPath, systemFilePerm); err != nil {
+		return fmt.Errorf("failed to set lock file permissions: %w", err)
+	}
+
 	return nil
 }
 
 // canUseSystemStorage checks if the current user can use system-wide storage
-func canUseSystemStorage(filePath, cacheDir string) bool {
+func canUseSystemStorage(filePath, archivePath, cacheDir string) bool {
 	// Check if we can write to the storage file
 	if !canWriteToFile(filePath) {
 		return false
 	}
 
+	// Fetches archive their items, except into the database of the bolt
+	// backend
+	if config.GetStorageBackend() != config.StorageBackendBolt && !canWriteToFile(archivePath) {
+		return false
+	}
+
 	// Check if we can write to the cache directory
 	if !canWriteToDirectory(cacheDir) {
 		return false
 	}
 
+	// Updates need the lock file, which only root can create
+	if _, err := os.Stat(lockPathFor(filePath)); err != nil && !canWriteToDirectory(filepath.Dir(filePath)) {
+		return false
+	}
+
 	return true
 }
 
@@ -202,8 +417,14 @@ func getUserStoragePaths() (string, string, error) {
 		return "", "", fmt.Errorf("failed to get config path: %w", err)
 	}
 
-	filePath := filepath.Join(configPath, ".informant_read_status.json")
-	cacheDir := filepath.Join(configPath, ".informant_cache")
+	filePath := filepath.Join(configPath, userFileName)
+	cacheDir := filepath.Join(configPath, userCacheName)
+	if dir := platform.UserCacheDir(); dir != "" {
+		cacheDir = dir
+	}
+	if dir := config.GetCacheDir(); dir != "" {
+		cacheDir = dir
+	}
 
 	// Create cache directory
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
@@ -213,10 +434,35 @@ func getUserStoragePaths() (string, string, error) {
 	return filePath, cacheDir, nil
 }
 
+// getCustomStoragePaths returns the storage paths for a configured read
+// status file, creating its directory. The cache lives next to it unless a
+// cache directory is configured too.
+func getCustomStoragePaths(filePath string) (string, string, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	cacheDir := config.GetCacheDir()
+	if cacheDir == "" {
+		cacheDir = siblingPath(filePath, "-cache", "")
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return filePath, cacheDir, nil
+}
+
+// siblingPath derives a path next to the read status file by replacing its
+// extension, e.g. status.json becomes status-archive.json
+func siblingPath(filePath, suffix, ext string) string {
+	return strings.TrimSuffix(filePath, filepath.Ext(filePath)) + suffix + ext
+}
+
 // confirmFallback asks user for confirmation to use per-user storage
 func confirmFallback() bool {
 	showStorageFallbackWarning()
-	fmt.Print("Continue with per-user storage? [y/N]: ")
+	fmt.Print(i18n.T("Continue with per-user storage? [y/N]: "))
 
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
@@ -224,12 +470,19 @@ func confirmFallback() bool {
 		return false
 	}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes"
+	return i18n.IsYes(response)
 }
 
 // GetCacheFile returns cached RSS data if available and not expired
 func (s *Storage) GetCacheFile(url string, maxAge time.Duration) ([]byte, bool) {
+	if s.bolt != nil {
+		entry, found := s.bolt.getCache(url)
+		if !found || !entry.fresh(maxAge) {
+			return nil, false
+		}
+		return entry.Data, true
+	}
+
 	cacheFile := s.getCacheFilePath(url)
 
 	data, err := os.ReadFile(cacheFile)
@@ -243,7 +496,7 @@ func (s *Storage) GetCacheFile(url string, maxAge time.Duration) ([]byte, bool)
 	}
 
 	// Check if cache is still valid
-	if time.Since(entry.Timestamp) > maxAge {
+	if !entry.fresh(maxAge) {
 		return nil, false
 	}
 
@@ -252,12 +505,31 @@ func (s *Storage) GetCacheFile(url string, maxAge time.Duration) ([]byte, bool)
 
 // SetCacheFile saves RSS data to cache
 func (s *Storage) SetCacheFile(url string, data []byte) error {
-	cacheFile := s.getCacheFilePath(url)
+	return s.setCacheEntry(CacheEntry{
+		Data:      data,
+		Timestamp: time.Now(),
+		URL:       url,
+	})
+}
 
-	entry := CacheEntry{
+// SetCacheFileUntil saves RSS data to cache, to be used until expires
+// regardless of the age GetCacheFile is asked for
+func (s *Storage) SetCacheFileUntil(url string, data []byte, expires time.Time) error {
+	return s.setCacheEntry(CacheEntry{
 		Data:      data,
 		Timestamp: time.Now(),
 		URL:       url,
+		Expires:   expires,
+	})
+}
+
+// setCacheEntry writes a cache entry to the bolt database or the cache
+// directory
+func (s *Storage) setCacheEntry(entry CacheEntry) error {
+	cacheFile := s.getCacheFilePath(entry.URL)
+
+	if s.bolt != nil {
+		return s.bolt.setCache(entry)
 	}
 
 	jsonData, err := json.Marshal(entry)
@@ -273,11 +545,15 @@ func (s *Storage) SetCacheFile(url string, data []byte) error {
 	}
 
 	// Write cache file directly
-	if err := os.WriteFile(cacheFile, jsonData, 0666); err != nil {
+	created := !exists(cacheFile)
+	if err := os.WriteFile(cacheFile, jsonData, s.filePerm()); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
-	return nil
+	if !created {
+		return nil
+	}
+	return s.share(cacheFile, systemFilePerm)
 }
 
 // getCacheFilePath generates a cache file path for a URL
@@ -302,16 +578,305 @@ func (s *Storage) MarkAsRead(itemID string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.status.ReadItems[itemID] = time.Now()
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
+	s.status.ReadItems[itemID] = now
+	delete(s.status.UnreadItems, itemID)
+	if s.perItem {
+		if err := s.writeItemFile(itemID, true, now); err != nil {
+			return err
+		}
+	}
+	logging.Event(logging.LevelDebug, "Items marked as read", logging.Fields{"count": 1})
 	return s.save()
 }
 
+// MarkAllAsRead marks several items as read with a single save
+func (s *Storage) MarkAllAsRead(itemIDs []string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
+	for _, itemID := range itemIDs {
+		s.status.ReadItems[itemID] = now
+		delete(s.status.UnreadItems, itemID)
+		if s.perItem {
+			if err := s.writeItemFile(itemID, true, now); err != nil {
+				return err
+			}
+		}
+	}
+	logging.Event(logging.LevelDebug, "Items marked as read", logging.Fields{"count": len(itemIDs)})
+	return s.save()
+}
+
+// MarkInitialRead marks the items as read while the read status is new, so
+// that only items published later show up as unread. itemIDs holds the item
+// IDs of every feed fetched, by feed URL; a feed is only marked once, so
+// that later runs with other profiles do not mark its new items. Items with
+// a mark of their own are left alone. The read status stays new until every
+// feed in allFeeds has been marked. It returns how many items it marked.
+func (s *Storage) MarkInitialRead(itemIDs map[string][]string, allFeeds []string) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.status.InitialReadPending {
+		return 0, nil
+	}
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	// Another process may have finished it in the meantime
+	if !s.status.InitialReadPending {
+		return 0, nil
+	}
+	if s.status.InitialReadFeeds == nil {
+		s.status.InitialReadFeeds = make(map[string]time.Time)
+	}
+
+	now := time.Now()
+	count := 0
+	for url, ids := range itemIDs {
+		if _, done := s.status.InitialReadFeeds[url]; done {
+			continue
+		}
+		for _, itemID := range ids {
+			if _, read := s.status.ReadItems[itemID]; read {
+				continue
+			}
+			if _, unread := s.status.UnreadItems[itemID]; unread {
+				continue
+			}
+			s.status.ReadItems[itemID] = now
+			if s.perItem {
+				if err := s.writeItemFile(itemID, true, now); err != nil {
+					return count, err
+				}
+			}
+			count++
+		}
+		s.status.InitialReadFeeds[url] = now
+	}
+
+	complete := true
+	for _, url := range allFeeds {
+		if _, done := s.status.InitialReadFeeds[url]; !done {
+			complete = false
+			break
+		}
+	}
+	if complete {
+		s.status.InitialReadPending = false
+		s.status.InitialReadFeeds = nil
+	}
+
+	return count, s.save()
+}
+
 // MarkAsUnread marks an item as unread
 func (s *Storage) MarkAsUnread(itemID string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
 	delete(s.status.ReadItems, itemID)
+	if s.status.UnreadItems == nil {
+		s.status.UnreadItems = make(map[string]time.Time)
+	}
+	s.status.UnreadItems[itemID] = now
+	if s.perItem {
+		if err := s.writeItemFile(itemID, false, now); err != nil {
+			return err
+		}
+	}
+	return s.save()
+}
+
+// IsNotified checks if a notification has already been sent for an item
+func (s *Storage) IsNotified(itemID string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.status.NotifiedItems[itemID]
+	return exists
+}
+
+// MarkAsNotified records that a notification has been sent for the given items
+func (s *Storage) MarkAsNotified(itemIDs ...string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if s.status.NotifiedItems == nil {
+		s.status.NotifiedItems = make(map[string]time.Time)
+	}
+
+	now := time.Now()
+	for _, itemID := range itemIDs {
+		s.status.NotifiedItems[itemID] = now
+	}
+	return s.save()
+}
+
+// IsStarred checks if an item has been starred
+func (s *Storage) IsStarred(itemID string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.status.StarredItems[itemID]
+	return exists
+}
+
+// SetStarred stars or unstars an item
+func (s *Storage) SetStarred(itemID string, starred bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !starred {
+		delete(s.status.StarredItems, itemID)
+		return s.save()
+	}
+
+	if s.status.StarredItems == nil {
+		s.status.StarredItems = make(map[string]time.Time)
+	}
+	s.status.StarredItems[itemID] = time.Now()
+	return s.save()
+}
+
+// RecordFetches updates the per-feed status with the outcome of fetching
+// feeds. Feed health is local information, so this never triggers a sync.
+func (s *Storage) RecordFetches(fetches []FeedFetch) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if s.status.Feeds == nil {
+		s.status.Feeds = make(map[string]FeedStatus)
+	}
+
+	now := time.Now()
+	for _, fetch := range fetches {
+		status := s.status.Feeds[fetch.URL]
+		if fetch.Err != nil {
+			status.LastError = fetch.Err.Error()
+			status.LastErrorAt = now
+		} else if fetch.Cached {
+			// The server was not asked, so its health is unchanged
+			status.ItemCount = fetch.Items
+		} else {
+			status.LastSuccess = now
+			status.LastError = ""
+			status.LastErrorAt = time.Time{}
+			status.ItemCount = fetch.Items
+		}
+		s.status.Feeds[fetch.URL] = status
+	}
+
+	return s.writeStatus()
+}
+
+// GetFeedStatus returns the recorded status of a feed
+func (s *Storage) GetFeedStatus(url string) (FeedStatus, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	status, ok := s.status.Feeds[url]
+	return status, ok
+}
+
+// MigrateLegacyKeys moves state stored under raw item IDs, as written by
+// older versions, to the items' feed-namespaced keys. Every item in the
+// batch that shares a legacy ID inherits its state before the legacy entry
+// is removed. Items explicitly marked unread since are left alone.
+func (s *Storage) MigrateLegacyKeys(items []feed.Item) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	legacy := make(map[string]bool)
+	for _, item := range items {
+		key := item.Key()
+		if key == item.ID {
+			continue
+		}
+
+		if readTime, ok := s.status.ReadItems[item.ID]; ok {
+			legacy[item.ID] = true
+			_, read := s.status.ReadItems[key]
+			_, unread := s.status.UnreadItems[key]
+			if !read && !unread {
+				s.status.ReadItems[key] = readTime
+				if s.perItem {
+					if err := s.writeItemFile(key, true, readTime); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if notifiedTime, ok := s.status.NotifiedItems[item.ID]; ok {
+			legacy[item.ID] = true
+			if _, notified := s.status.NotifiedItems[key]; !notified {
+				s.status.NotifiedItems[key] = notifiedTime
+			}
+		}
+	}
+
+	if len(legacy) == 0 {
+		return nil
+	}
+
+	for id := range legacy {
+		delete(s.status.ReadItems, id)
+		delete(s.status.NotifiedItems, id)
+		if s.perItem {
+			os.Remove(s.itemFilePath(id))
+		}
+	}
+
 	return s.save()
 }
 
@@ -334,18 +899,8 @@ func (s *Storage) GetReadCount() int {
 
 // Cleanup removes read status for items older than the specified duration
 func (s *Storage) Cleanup(maxAge time.Duration) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
-	cutoff := time.Now().Add(-maxAge)
-
-	for itemID, readTime := range s.status.ReadItems {
-		if readTime.Before(cutoff) {
-			delete(s.status.ReadItems, itemID)
-		}
-	}
-
-	return s.save()
+	_, err := s.Prune(maxAge, false)
+	return err
 }
 
 // IsSystemWide returns whether storage is system-wide or per-user
@@ -353,18 +908,67 @@ func (s *Storage) IsSystemWide() bool {
 	return s.isSystemWide
 }
 
-// load reads the read status from disk
+// load reads the read status from disk, upgrading it to the current schema
+// version. Upgraded status is written back in place.
 func (s *Storage) load() error {
-	data, err := os.ReadFile(s.filePath)
+	status, migrated, err := s.readStatus()
 	if err != nil {
 		return err
 	}
+	*s.status = *status
+
+	if migrated {
+		if err := s.writeStatus(); err != nil {
+			logging.Warnf("Failed to save migrated read status: %v", err)
+		}
+	}
 
-	return json.Unmarshal(data, s.status)
+	return nil
 }
 
-// save writes the current read status to disk
+// readStatus reads the read status from disk and upgrades it to the current
+// schema version, reporting whether it had to be upgraded
+func (s *Storage) readStatus() (*ReadStatus, bool, error) {
+	var status ReadStatus
+	if s.bolt != nil {
+		if err := s.bolt.loadStatus(&status); err != nil {
+			return nil, false, err
+		}
+	} else {
+		data, err := os.ReadFile(s.filePath)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := json.Unmarshal(data, &status); err != nil {
+			return nil, false, err
+		}
+	}
+
+	migrated, err := migrateStatus(&status)
+	if err != nil {
+		return nil, false, err
+	}
+	return &status, migrated, nil
+}
+
+// save writes the current read status to disk and records the change for
+// AutoSync
 func (s *Storage) save() error {
+	if err := s.writeStatus(); err != nil {
+		return err
+	}
+
+	s.changed = true
+	return nil
+}
+
+// writeStatus writes the current read status to disk
+func (s *Storage) writeStatus() error {
+	if s.bolt != nil {
+		s.status.LastCheck = time.Now()
+		return s.bolt.saveStatus(s.status)
+	}
+
 	// Ensure directory exists (only if we have permission)
 	dir := filepath.Dir(s.filePath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -380,28 +984,155 @@ func (s *Storage) save() error {
 		return fmt.Errorf("failed to marshal read status: %w", err)
 	}
 
-	// Set appropriate permissions based on whether we're using system-wide storage
-	var perm os.FileMode = 0644
-	if s.isSystemWide {
-		perm = 0666
+	created := !exists(s.filePath)
+	if err := writeFileAtomic(s.filePath, data, s.filePerm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
 	}
 
-	// Write directly to the file in-place
-	if err := os.WriteFile(s.filePath, data, perm); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	if !created {
+		return nil
 	}
+	return s.share(s.filePath, systemFilePerm)
+}
 
-	// Set final permissions on the file if system-wide and we're root
-	if s.isSystemWide && os.Geteuid() == 0 {
-		// Check if file already has correct permissions
-		if info, err := os.Stat(s.filePath); err == nil {
-			if info.Mode().Perm() != 0666 {
-				if err := os.Chmod(s.filePath, 0666); err != nil {
-					return fmt.Errorf("failed to set file permissions: %w", err)
-				}
-			}
+// ArchiveItems stores items in the offline archive, replacing older copies
+// of the same items
+func (s *Storage) ArchiveItems(items []feed.Item) error {
+	s.archiveMutex.Lock()
+	defer s.archiveMutex.Unlock()
+
+	for _, item := range items {
+		s.archive.Items[item.Key()] = item
+	}
+
+	return s.saveArchive()
+}
+
+// ArchivedItems returns every item in the offline archive
+func (s *Storage) ArchivedItems() []feed.Item {
+	s.archiveMutex.RLock()
+	defer s.archiveMutex.RUnlock()
+
+	items := make([]feed.Item, 0, len(s.archive.Items))
+	for _, item := range s.archive.Items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// loadArchive reads the offline archive from disk, starting empty if it
+// does not exist yet
+func (s *Storage) loadArchive() error {
+	s.archive = &Archive{Items: make(map[string]feed.Item)}
+
+	if s.bolt != nil {
+		if err := s.bolt.loadArchive(s.archive); err != nil {
+			return err
+		}
+		s.dedupeArchive()
+		return nil
+	}
+
+	data, err := os.ReadFile(s.archivePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(data, s.archive); err != nil {
+		return err
+	}
+	if s.archive.Items == nil {
+		s.archive.Items = make(map[string]feed.Item)
+	}
+
+	s.dedupeArchive()
+	return nil
+}
+
+// dedupeArchive keeps a single copy of items archived under their raw ID by
+// older versions, under their feed-namespaced key
+func (s *Storage) dedupeArchive() {
+	for key, item := range s.archive.Items {
+		current := item.Key()
+		if current == key {
+			continue
+		}
+		if _, ok := s.archive.Items[current]; !ok {
+			s.archive.Items[current] = item
+		}
+		delete(s.archive.Items, key)
+	}
+}
+
+// saveArchive writes the offline archive to disk
+func (s *Storage) saveArchive() error {
+	if s.bolt != nil {
+		return s.bolt.saveArchive(s.archive)
+	}
+
+	data, err := json.Marshal(s.archive)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item archive: %w", err)
+	}
+
+	created := !exists(s.archivePath)
+	if err := writeFileAtomic(s.archivePath, data, s.filePerm()); err != nil {
+		return fmt.Errorf("failed to write item archive: %w", err)
+	}
+
+	if !created {
+		return nil
+	}
+	return s.share(s.archivePath, systemFilePerm)
+}
+
+// writeFileAtomic replaces the file at path with data through a temporary
+// file in the same directory, so that an interrupted write never leaves it
+// truncated. An existing file keeps its mode, owner and group. Group members
+// who may write the system-wide files but not create files in /var/lib
+// write them in place instead.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		if os.IsPermission(err) {
+			return os.WriteFile(path, data, perm)
 		}
+		return err
 	}
+	tmpPath := tmp.Name()
 
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+			// Only root can give the file to another owner
+			tmp.Chown(int(stat.Uid), int(stat.Gid))
+		}
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
 	return nil
 }
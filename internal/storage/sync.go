@@ -0,0 +1,277 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"informant/internal/config"
+	"informant/internal/logging"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Remote stores a copy of the read status on a remote server
+type Remote interface {
+	// Pull downloads the remote read status. found is false when the
+	// remote copy does not exist yet.
+	Pull(ctx context.Context) (data []byte, found bool, err error)
+	// Push uploads the read status, replacing the remote copy
+	Push(ctx context.Context, data []byte) error
+}
+
+// syncClient is used for all remote sync requests
+var syncClient = &http.Client{Timeout: 30 * time.Second}
+
+// newRemote creates the Remote for a sync configuration
+func newRemote(cfg *config.Sync) (Remote, error) {
+	switch cfg.Type {
+	case "webdav":
+		return &webdavRemote{cfg: cfg}, nil
+	case "s3":
+		return &s3Remote{cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("unknown sync type: %q", cfg.Type)
+	}
+}
+
+// SyncResult summarizes the changes made by a sync
+type SyncResult struct {
+	Pulled int
+}
+
+// HasRemote returns whether remote sync is configured
+func (s *Storage) HasRemote() bool {
+	return s.remote != nil
+}
+
+// Sync pulls the remote read status, merges it into the local one and pushes
+// the merged result back. The locks are only held while merging, never
+// during the requests, so other processes such as the pacman hook do not
+// wait on the remote.
+func (s *Storage) Sync() (SyncResult, error) {
+	var result SyncResult
+
+	if s.remote == nil {
+		return result, fmt.Errorf("remote sync is not configured")
+	}
+
+	data, found, err := s.remote.Pull(s.context())
+	if err != nil {
+		return result, fmt.Errorf("failed to pull read status: %w", err)
+	}
+
+	var remote *ReadStatus
+	if found {
+		remote = &ReadStatus{}
+		if err := json.Unmarshal(data, remote); err != nil {
+			return result, fmt.Errorf("failed to parse remote read status: %w", err)
+		}
+		if _, err := migrateStatus(remote); err != nil {
+			return result, fmt.Errorf("failed to migrate remote read status: %w", err)
+		}
+	}
+
+	merged, err := s.mergeRemote(remote, &result)
+	if err != nil {
+		return result, err
+	}
+
+	if err := s.remote.Push(s.context(), merged); err != nil {
+		// The changes still need pushing
+		s.mutex.Lock()
+		s.changed = true
+		s.mutex.Unlock()
+		return result, fmt.Errorf("failed to push read status: %w", err)
+	}
+
+	return result, nil
+}
+
+// mergeRemote merges the pulled read status, if any, into the local one under
+// the locks, and returns the merged status to push
+func (s *Storage) mergeRemote(remote *ReadStatus, result *SyncResult) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	unlock, err := s.lockAndRefresh()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if remote != nil {
+		if result.Pulled, err = s.mergeStatus(remote); err != nil {
+			return nil, err
+		}
+		if err := s.writeStatus(); err != nil {
+			return nil, err
+		}
+	}
+
+	merged, err := json.Marshal(s.status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal read status: %w", err)
+	}
+	// Changes made from here on are pushed by the next sync
+	s.changed = false
+
+	return merged, nil
+}
+
+// mergeStatus merges another read status into the local one. For every item
+// the most recent read or unread mark wins. It returns the number of items
+// whose local state changed.
+func (s *Storage) mergeStatus(other *ReadStatus) (int, error) {
+	s.adoptPruneCutoff(other)
+
+	if s.status.ReadItems == nil {
+		s.status.ReadItems = make(map[string]time.Time)
+	}
+	if s.status.UnreadItems == nil {
+		s.status.UnreadItems = make(map[string]time.Time)
+	}
+
+	ids := make(map[string]bool)
+	for id := range other.ReadItems {
+		ids[id] = true
+	}
+	for id := range other.UnreadItems {
+		ids[id] = true
+	}
+
+	changed := 0
+	for id := range ids {
+		localRead, wasRead := s.status.ReadItems[id]
+		latest := latestMark(localRead, s.status.UnreadItems[id])
+		remoteLatest := latestMark(other.ReadItems[id], other.UnreadItems[id])
+
+		if !remoteLatest.After(latest) || remoteLatest.Before(s.status.PrunedBefore) {
+			continue
+		}
+
+		if readAt, ok := other.ReadItems[id]; ok && readAt.Equal(remoteLatest) {
+			s.status.ReadItems[id] = readAt
+			delete(s.status.UnreadItems, id)
+		} else {
+			delete(s.status.ReadItems, id)
+			s.status.UnreadItems[id] = remoteLatest
+		}
+
+		_, isRead := s.status.ReadItems[id]
+		if isRead != wasRead {
+			changed++
+		}
+
+		if s.perItem {
+			if err := s.writeItemFile(id, isRead, remoteLatest); err != nil {
+				return changed, err
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// latestMark returns the later of a read and an unread mark time
+func latestMark(read, unread time.Time) time.Time {
+	if unread.After(read) {
+		return unread
+	}
+	return read
+}
+
+// AutoSync syncs the read status with the remote when auto-sync is enabled
+// and the read status changed since the storage was opened or last synced.
+// Commands call it once when they finish. Failures only produce a warning so
+// that marking items works offline.
+func (s *Storage) AutoSync() {
+	s.mutex.RLock()
+	changed := s.changed
+	s.mutex.RUnlock()
+
+	if s.remote == nil || !s.autoSyncEnabled || !changed {
+		return
+	}
+
+	if _, err := s.Sync(); err != nil {
+		logging.Warnf("Failed to sync read status: %v", err)
+	}
+}
+
+// webdavRemote stores the read status as a single file on a WebDAV server
+type webdavRemote struct {
+	cfg *config.Sync
+}
+
+func (r *webdavRemote) Pull(ctx context.Context) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.cfg.URL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if r.cfg.Username != "" {
+		req.SetBasicAuth(r.cfg.Username, r.cfg.Password)
+	}
+
+	return doPull(req)
+}
+
+func (r *webdavRemote) Push(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.cfg.URL, bytesReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.cfg.Username != "" {
+		req.SetBasicAuth(r.cfg.Username, r.cfg.Password)
+	}
+
+	return doPush(req)
+}
+
+// doPull performs a download request, treating 404 as a missing remote copy
+func doPull(req *http.Request) ([]byte, bool, error) {
+	resp, err := syncClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+// doPush performs an upload request
+func doPush(req *http.Request) error {
+	resp, err := syncClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// bytesReader returns a reader for a request body, or nil for an empty body
+func bytesReader(data []byte) io.Reader {
+	if data == nil {
+		return nil
+	}
+	return bytes.NewReader(data)
+}
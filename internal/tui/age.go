@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"informant/internal/i18n"
+	"time"
+)
+
+// RelativeTime describes how long before now t was, like "3 days ago"
+func RelativeTime(t, now time.Time) string {
+	age := now.Sub(t)
+	if age < 0 {
+		age = 0
+	}
+
+	days := int(age.Hours() / 24)
+	switch {
+	case age < time.Minute:
+		return i18n.T("just now")
+	case age < time.Hour:
+		return plural(int(age.Minutes()), "1 minute ago", "%d minutes ago")
+	case age < 24*time.Hour:
+		return plural(int(age.Hours()), "1 hour ago", "%d hours ago")
+	case days < 30:
+		return plural(days, "1 day ago", "%d days ago")
+	case days < 365:
+		return plural(days/30, "1 month ago", "%d months ago")
+	default:
+		return plural(days/365, "1 year ago", "%d years ago")
+	}
+}
+
+// plural picks the translated singular or plural message for n
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return i18n.T(one)
+	}
+	return i18n.T(many, n)
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"informant/internal/feed"
+	"informant/internal/i18n"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Widths of the fixed-size columns
+const (
+	statusColumnWidth = 1
+	starColumnWidth   = 1
+	dateColumnWidth   = 10
+)
+
+// Upper bounds for columns sized to their content, so that long feed names,
+// authors or tag lists cannot squeeze the title
+const (
+	maxFeedColumnWidth   = 20
+	maxAuthorColumnWidth = 20
+	maxTagsColumnWidth   = 24
+	minTitleColumnWidth  = 10
+)
+
+// columnWidths allocates the available width between the columns. Fixed
+// columns get their width, content columns the width of their longest value
+// up to a cap, and the title takes whatever is left. Content columns that
+// are empty for every item get no space at all.
+func columnWidths(items []feed.Item, columns []string, width int) []int {
+	widths := make([]int, len(columns))
+	titleIndex := -1
+	used := 0
+
+	for i, column := range columns {
+		switch column {
+		case "index":
+			widths[i] = len(strconv.Itoa(len(items)))
+		case "status":
+			widths[i] = statusColumnWidth
+		case "star":
+			widths[i] = starColumnWidth
+		case "date":
+			widths[i] = dateColumnWidth
+		case "feed":
+			widths[i] = contentWidth(items, column, maxFeedColumnWidth)
+		case "author":
+			widths[i] = contentWidth(items, column, maxAuthorColumnWidth)
+		case "tags":
+			widths[i] = contentWidth(items, column, maxTagsColumnWidth)
+		case "title":
+			titleIndex = i
+			continue
+		}
+		if widths[i] > 0 {
+			used += widths[i] + 1
+		}
+	}
+
+	if titleIndex >= 0 {
+		widths[titleIndex] = width - used
+		if widths[titleIndex] < minTitleColumnWidth {
+			widths[titleIndex] = minTitleColumnWidth
+		}
+	}
+
+	return widths
+}
+
+// contentWidth returns the display width of the longest value of a column,
+// capped at max
+func contentWidth(items []feed.Item, column string, max int) int {
+	width := 0
+	for _, item := range items {
+		if n := runewidth.StringWidth(columnValue(row{item: item}, column)); n > width {
+			width = n
+		}
+	}
+	if width > max {
+		width = max
+	}
+	return width
+}
+
+// row is an item together with the state shown alongside it
+type row struct {
+	item      feed.Item
+	index     int
+	isRead    bool
+	isStarred bool
+	isLater   bool
+	// isNew is set for unread items that arrived with an auto-refresh
+	isNew bool
+}
+
+// columnValue returns the text shown in a column for a row
+func columnValue(r row, column string) string {
+	item := r.item
+	switch column {
+	case "index":
+		return strconv.Itoa(r.index)
+	case "star":
+		if r.isStarred {
+			return "*"
+		}
+		if r.isLater {
+			return "L"
+		}
+		return ""
+	case "status":
+		if item.Highlighted {
+			if r.isRead {
+				return glyphs.highlightedRead
+			}
+			return glyphs.highlightedUnread
+		}
+		if r.isRead {
+			return glyphs.read
+		}
+		return glyphs.unread
+	case "date":
+		return FormatTime(item.Published, "2006-01-02")
+	case "feed":
+		return item.FeedName
+	case "title":
+		if r.isNew {
+			return i18n.T("NEW") + " " + item.Title
+		}
+		return item.Title
+	case "tags":
+		return strings.Join(item.Tags, ", ")
+	case "author":
+		return item.Author
+	default:
+		return ""
+	}
+}
+
+// renderColumns formats a row as a line of padded columns
+func renderColumns(r row, columns []string, widths []int) string {
+	var cells []string
+	for i, column := range columns {
+		if widths[i] == 0 {
+			continue
+		}
+		value := columnValue(r, column)
+		if column == "index" {
+			// Right-align indexes like a numbered list
+			value = strings.Repeat(" ", widths[i]-len(value)) + value
+		}
+		cells = append(cells, fitWidth(value, widths[i]))
+	}
+	return strings.TrimRight(strings.Join(cells, " "), " ")
+}
+
+// fitWidth pads or truncates text to exactly width terminal cells. Wide
+// characters such as CJK and emoji take two cells; one cut in half is
+// replaced by padding.
+func fitWidth(text string, width int) string {
+	if runewidth.StringWidth(text) > width {
+		tail := "..."
+		if width <= len(tail) {
+			tail = ""
+		}
+		text = runewidth.Truncate(text, width, tail)
+	}
+	return runewidth.FillRight(text, width)
+}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"informant/internal/i18n"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+// FeedError is a feed that could not be fetched or parsed
+type FeedError struct {
+	Name  string
+	URL   string
+	Error string
+}
+
+// RetryFunc fetches the feed with the given URL again and returns the
+// message that updates the TUI, an ItemsMsg or an error
+type RetryFunc func(url string) tea.Msg
+
+// SetFeedErrors sets the feeds listed in the errors view
+func (m *Model) SetFeedErrors(errors []FeedError) {
+	m.feedErrors = errors
+	if m.errorCursor >= len(errors) {
+		m.errorCursor = 0
+	}
+}
+
+// SetRetry sets how the errors view retries a feed. Without it, feeds cannot
+// be retried from the TUI.
+func (m *Model) SetRetry(retry RetryFunc) {
+	m.retry = retry
+}
+
+// updateErrorsView handles key events in the errors view
+func (m Model) updateErrorsView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "escape", "e":
+		m.viewMode = ViewList
+
+	case "?":
+		m.showHelp = true
+
+	case "j", "down":
+		if m.errorCursor < len(m.feedErrors)-1 {
+			m.errorCursor++
+		}
+
+	case "k", "up":
+		if m.errorCursor > 0 {
+			m.errorCursor--
+		}
+
+	case "r", "enter":
+		if m.retry == nil || m.retrying != "" || len(m.feedErrors) == 0 {
+			break
+		}
+		url := m.feedErrors[m.errorCursor].URL
+		m.retrying = url
+		retry := m.retry
+		return m, func() tea.Msg { return retry(url) }
+	}
+
+	return m, nil
+}
+
+// retryToast reports whether retrying the feed with the given URL worked
+func (m *Model) retryToast(url string) tea.Cmd {
+	for _, feedErr := range m.feedErrors {
+		if feedErr.URL == url {
+			return m.setToast(i18n.T("Feed refresh failed: %s", feedErr.Error), true)
+		}
+	}
+	return m.showToast(i18n.T("Fetched %s", url))
+}
+
+// renderErrorsView lists the feeds that failed with their errors
+func (m Model) renderErrorsView() string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render(i18n.T("Feed Errors")) + "\n")
+
+	status := i18n.T("Failed feeds: %d", len(m.feedErrors))
+	if m.retrying != "" {
+		status += " | " + i18n.T("Retrying %s...", m.retrying)
+	}
+	b.WriteString(statusStyle.Render(status) + "\n\n")
+
+	if len(m.feedErrors) == 0 {
+		b.WriteString(dimmedStyle.Render(i18n.T("All feeds were fetched successfully.")) + "\n")
+	}
+
+	width := m.width - 4
+	for i, feedErr := range m.feedErrors {
+		name := feedErr.Name
+		if name == "" {
+			name = feedErr.URL
+		}
+
+		marker := "  "
+		style := itemStyle
+		if i == m.errorCursor {
+			marker = glyphs.cursor + " "
+			style = selectedItemStyle
+		}
+		b.WriteString(style.Render(marker+name) + " " + feedNameStyle.Render(feedErr.URL) + "\n")
+
+		message := feedErr.Error
+		if width > 0 {
+			message = runewidth.Truncate(message, width, "…")
+		}
+		b.WriteString("    " + errorStyle.Render(message) + "\n")
+	}
+
+	if toast := m.renderToast(); toast != "" {
+		b.WriteString("\n" + toast + "\n")
+	}
+
+	b.WriteString("\n" + helpStyle.Render(i18n.T("r: retry feed, q: back to list")))
+
+	return b.String()
+}
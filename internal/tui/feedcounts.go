@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// isPending reports whether an item counts as unread: not read, snoozed or
+// queued to read later
+func (m Model) isPending(key string) bool {
+	return !m.storage.IsRead(key) && !m.storage.IsSnoozed(key) && !m.storage.IsLater(key)
+}
+
+// feedSummary returns the unread count of every feed, like
+// "Arch Linux News: 2 • Security: 5", cut to the width of the terminal. It
+// is empty unless several feeds are configured.
+func (m Model) feedSummary() string {
+	if len(m.feeds) < 2 {
+		return ""
+	}
+
+	unread := make(map[string]int, len(m.feeds))
+	for _, item := range m.allItems {
+		if m.isPending(item.Key()) {
+			unread[item.FeedName]++
+		}
+	}
+
+	parts := make([]string, len(m.feeds))
+	for i, name := range m.feeds {
+		parts[i] = fmt.Sprintf("%s: %d", name, unread[name])
+	}
+	summary := strings.Join(parts, " "+glyphs.separator+" ")
+
+	if m.width > 0 {
+		summary = runewidth.Truncate(summary, m.width, "…")
+	}
+	return summary
+}
+
+// headerLines is the number of lines above the item list
+func (m Model) headerLines() int {
+	if m.feedSummary() != "" {
+		return 4
+	}
+	return 3
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// glyphSet holds the symbols drawn in the item list and help
+type glyphSet struct {
+	unread            string
+	read              string
+	highlightedUnread string
+	highlightedRead   string
+	cursor            string
+	down              string
+	up                string
+	separator         string
+}
+
+// unicodeGlyphs are used by default
+var unicodeGlyphs = glyphSet{
+	unread:            "●",
+	read:              "○",
+	highlightedUnread: "★",
+	highlightedRead:   "☆",
+	cursor:            "▶",
+	down:              "↓",
+	up:                "↑",
+	separator:         "•",
+}
+
+// asciiGlyphs replace them in plain mode, for terminals and fonts without
+// these symbols
+var asciiGlyphs = glyphSet{
+	unread:            "N",
+	read:              "-",
+	highlightedUnread: "!",
+	highlightedRead:   "i",
+	cursor:            ">",
+	down:              "Down",
+	up:                "Up",
+	separator:         "|",
+}
+
+var glyphs = unicodeGlyphs
+
+// plainStyles is set when colors are turned off
+var plainStyles bool
+
+// SetPlain drops all colors from the TUI and command line styles and draws
+// ASCII glyphs instead of symbols, for NO_COLOR and --no-color. Bold and
+// the cursor marker still tell items apart.
+func SetPlain(plain bool) {
+	plainStyles = plain
+	if plain {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		glyphs = asciiGlyphs
+	} else {
+		glyphs = unicodeGlyphs
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+)
+
+// RenderMarkdown renders item Markdown for the terminal, word-wrapped to
+// width. Plain rendering uses no colors, for NO_COLOR and non-terminals.
+func RenderMarkdown(markdown string, width int, plain bool) (string, error) {
+	style := "dark"
+	if plain {
+		style = "notty"
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(style),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := renderer.Render(markdown)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(rendered, "\n"), nil
+}
+
+// renderContent prepares the lines of the selected item shown by the
+// reader for the current width, rendering its Markdown when enabled and
+// falling back to the plain text content
+func (m *Model) renderContent() {
+	m.contentLines = nil
+	if m.selectedItem == nil {
+		return
+	}
+
+	if m.markdown && m.selectedItem.Markdown != "" && m.width > 0 {
+		// Leave room for the content box border and padding
+		rendered, err := RenderMarkdown(m.selectedItem.Markdown, m.width-8, plainStyles)
+		if err == nil {
+			m.contentLines = strings.Split(rendered, "\n")
+			return
+		}
+		m.err = err
+	}
+
+	// Wrapped here rather than by the content box, so that scrolling counts
+	// the lines actually shown
+	m.contentLines = wrapText(m.selectedItem.Content, m.width-8)
+}
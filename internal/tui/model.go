@@ -3,10 +3,14 @@ package tui
 import (
 	"fmt"
 	"informant/internal/feed"
+	"informant/internal/i18n"
 	"informant/internal/storage"
+	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
 )
 
 // ViewMode represents the current view in the TUI
@@ -15,53 +19,174 @@ type ViewMode int
 const (
 	ViewList ViewMode = iota
 	ViewReader
-	ViewHelp
+	ViewErrors
 )
 
+// SortMode is the order of the items in the list view
+type SortMode int
+
+const (
+	SortNewestFirst SortMode = iota
+	SortOldestFirst
+	SortUnreadFirst
+	SortByFeed
+)
+
+// String returns the name of the sort mode shown in the status bar
+func (s SortMode) String() string {
+	switch s {
+	case SortOldestFirst:
+		return i18n.T("oldest first")
+	case SortUnreadFirst:
+		return i18n.T("unread first")
+	case SortByFeed:
+		return i18n.T("by feed")
+	default:
+		return i18n.T("newest first")
+	}
+}
+
+// next returns the sort mode that follows s when cycling
+func (s SortMode) next() SortMode {
+	return (s + 1) % (SortByFeed + 1)
+}
+
 // Model represents the TUI model
 type Model struct {
+	allItems     []feed.Item
 	items        []feed.Item
+	feeds        []string
 	storage      *storage.Storage
+	columns      []string
+	indexes      map[string]int
+	sortMode     SortMode
+	starredOnly  bool
 	viewMode     ViewMode
 	cursor       int
 	selectedItem *feed.Item
+	contentLines []string
+	markdown     bool
 	width        int
 	height       int
 	scrollOffset int
 	showHelp     bool
+	notice       string
 	err          error
+
+	// Feeds that failed, shown in the errors view
+	feedErrors  []FeedError
+	errorCursor int
+	retry       RetryFunc
+	retrying    string
+
+	// Periodic refresh, and the items it brought in
+	refreshInterval time.Duration
+	refresh         func() ItemsMsg
+	newItems        map[string]bool
+
+	// Short-lived feedback on actions
+	toast        string
+	toastIsError bool
+	toastID      int
+}
+
+// ItemsMsg replaces the items shown by the TUI, for example after the
+// config was reloaded. Feeds and Columns, when set, also replace the feed
+// names counted in the header and the list columns. Errors always replaces
+// the feeds listed in the errors view.
+type ItemsMsg struct {
+	Items   []feed.Item
+	Feeds   []string
+	Columns []string
+	Errors  []FeedError
+}
+
+// NoticeMsg shows a one-line notice, such as an available update, in the
+// status bar
+type NoticeMsg struct {
+	Text string
 }
 
-// NewModel creates a new TUI model
-func NewModel(items []feed.Item, storage *storage.Storage) Model {
+// NewModel creates a new TUI model showing the given list columns. With
+// several feeds, their unread counts are shown above the list. With markdown
+// set, the reader renders item content as Markdown.
+func NewModel(items []feed.Item, storage *storage.Storage, feeds []string, columns []string, markdown bool) Model {
 	return Model{
+		allItems: items,
 		items:    items,
+		feeds:    feeds,
 		storage:  storage,
+		columns:  columns,
+		markdown: markdown,
+		indexes:  itemIndexes(items),
 		viewMode: ViewList,
 		cursor:   0,
 	}
 }
 
+// itemIndexes numbers items from 1 in the given order, which is the order
+// 'informant list' and 'informant read N' use
+func itemIndexes(items []feed.Item) map[string]int {
+	indexes := make(map[string]int, len(items))
+	for i, item := range items {
+		indexes[item.Key()] = i + 1
+	}
+	return indexes
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.scheduleRefresh()
 }
 
 // Update handles messages and updates the model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
+		m.resize(msg.Width, msg.Height)
+
+	case ItemsMsg:
+		retried := m.retrying
+		m.applyItems(msg)
+		if retried != "" {
+			return m, m.retryToast(retried)
+		}
+
+	case refreshTickMsg:
+		refresh := m.refresh
+		return m, func() tea.Msg {
+			return refreshedMsg{items: refresh()}
+		}
+
+	case refreshedMsg:
+		return m, m.applyRefresh(msg.items)
+
+	case NoticeMsg:
+		m.notice = msg.Text
+
+	case error:
+		m.retrying = ""
+		return m, m.showErrorToast(msg)
+
+	case clearToastMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
 
 	case tea.KeyMsg:
+		// Any key closes the help overlay
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+
 		switch m.viewMode {
 		case ViewList:
 			return m.updateListView(msg)
 		case ViewReader:
 			return m.updateReaderView(msg)
-		case ViewHelp:
-			return m.updateHelpView(msg)
+		case ViewErrors:
+			return m.updateErrorsView(msg)
 		}
 	}
 
@@ -75,7 +200,7 @@ func (m Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 
 	case "?":
-		m.viewMode = ViewHelp
+		m.showHelp = true
 		return m, nil
 
 	case "j", "down":
@@ -90,33 +215,73 @@ func (m Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.adjustScroll()
 		}
 
-	case "g":
+	case "g", "home":
 		m.cursor = 0
 		m.scrollOffset = 0
 
-	case "G":
-		m.cursor = len(m.items) - 1
-		m.adjustScroll()
+	case "G", "end":
+		if len(m.items) > 0 {
+			m.cursor = len(m.items) - 1
+			m.adjustScroll()
+		}
+
+	case "pgdown":
+		m.moveCursor(m.listPageSize())
+
+	case "pgup":
+		m.moveCursor(-m.listPageSize())
+
+	case "ctrl+d":
+		m.moveCursor(m.listPageSize() / 2)
+
+	case "ctrl+u":
+		m.moveCursor(-m.listPageSize() / 2)
+
+	case "s":
+		m.sortMode = m.sortMode.next()
+		m.refreshView()
+
+	case "b":
+		if len(m.items) > 0 {
+			return m, m.toggleStar(m.items[m.cursor].Key())
+		}
+
+	case "B":
+		m.starredOnly = !m.starredOnly
+		m.refreshView()
+
+	case "e":
+		m.viewMode = ViewErrors
+
+	case "z":
+		if len(m.items) > 0 {
+			return m, m.toggleSnooze(m.items[m.cursor].Key())
+		}
+
+	case "l":
+		if len(m.items) > 0 {
+			return m, m.toggleLater(m.items[m.cursor].Key())
+		}
+
+	case "A":
+		return m, m.markAllRead()
+
+	case "n", "tab":
+		m.moveToUnread(1)
+
+	case "p", "shift+tab":
+		m.moveToUnread(-1)
 
 	case "enter":
 		if len(m.items) > 0 {
 			m.selectedItem = &m.items[m.cursor]
 			m.viewMode = ViewReader
+			m.renderContent()
 		}
 
 	case "r":
-		// Toggle read status
 		if len(m.items) > 0 {
-			item := &m.items[m.cursor]
-			var err error
-			if m.storage.IsRead(item.ID) {
-				err = m.storage.MarkAsUnread(item.ID)
-			} else {
-				err = m.storage.MarkAsRead(item.ID)
-			}
-			if err != nil {
-				m.err = err
-			}
+			return m, m.toggleRead(m.items[m.cursor].Key())
 		}
 	}
 
@@ -129,48 +294,223 @@ func (m Model) updateReaderView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "q", "escape":
 		m.viewMode = ViewList
 		m.selectedItem = nil
+		m.contentLines = nil
+
+	case "?":
+		m.showHelp = true
+
+	case "b":
+		if m.selectedItem != nil {
+			return m, m.toggleStar(m.selectedItem.Key())
+		}
+
+	case "z":
+		if m.selectedItem != nil {
+			return m, m.toggleSnooze(m.selectedItem.Key())
+		}
+
+	case "l":
+		if m.selectedItem != nil {
+			return m, m.toggleLater(m.selectedItem.Key())
+		}
 
 	case "r":
-		// Toggle read status of current item
 		if m.selectedItem != nil {
-			var err error
-			if m.storage.IsRead(m.selectedItem.ID) {
-				err = m.storage.MarkAsUnread(m.selectedItem.ID)
-			} else {
-				err = m.storage.MarkAsRead(m.selectedItem.ID)
-			}
-			if err != nil {
-				m.err = err
-			}
+			return m, m.toggleRead(m.selectedItem.Key())
 		}
 
 	case "j", "down":
-		// Scroll content down
-		m.scrollOffset++
+		m.scrollContent(1)
 
 	case "k", "up":
-		// Scroll content up
-		if m.scrollOffset > 0 {
-			m.scrollOffset--
-		}
+		m.scrollContent(-1)
+
+	case "pgdown":
+		m.scrollContent(m.readerPageSize())
+
+	case "pgup":
+		m.scrollContent(-m.readerPageSize())
+
+	case "ctrl+d":
+		m.scrollContent(m.readerPageSize() / 2)
+
+	case "ctrl+u":
+		m.scrollContent(-m.readerPageSize() / 2)
+
+	case "g", "home":
+		m.scrollOffset = 0
+
+	case "G", "end":
+		m.scrollContent(len(m.contentLines))
 	}
 
 	return m, nil
 }
 
-// updateHelpView handles key events in help view
-func (m Model) updateHelpView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "escape", "?":
-		m.viewMode = ViewList
+// moveToUnread moves the cursor to the nearest unread item after (direction
+// 1) or before (direction -1) the current one. The cursor stays put when
+// there is none.
+func (m *Model) moveToUnread(direction int) {
+	for i := m.cursor + direction; i >= 0 && i < len(m.items); i += direction {
+		if !m.storage.IsRead(m.items[i].Key()) {
+			m.cursor = i
+			m.adjustScroll()
+			return
+		}
 	}
+}
 
-	return m, nil
+// applyItems shows the items, feeds, columns and errors of an ItemsMsg
+func (m *Model) applyItems(msg ItemsMsg) {
+	m.setItems(msg.Items)
+	if len(msg.Feeds) > 0 {
+		m.feeds = msg.Feeds
+	}
+	if len(msg.Columns) > 0 {
+		m.columns = msg.Columns
+	}
+	m.SetFeedErrors(msg.Errors)
+	m.retrying = ""
+}
+
+// setItems replaces the items, keeping the cursor on the same item when it
+// is still present. Items are expected newest first, the order that defines
+// their indexes.
+func (m *Model) setItems(items []feed.Item) {
+	m.allItems = items
+	m.indexes = itemIndexes(items)
+	m.refreshView()
+}
+
+// refreshView rebuilds the listed items from all items using the current
+// sort mode and filter, keeping the cursor on the same item when it is still
+// listed. Items that compare equal keep their list order, i.e. newest first.
+func (m *Model) refreshView() {
+	var items []feed.Item
+	for _, item := range m.allItems {
+		if m.starredOnly && !m.storage.IsStarred(item.Key()) {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	// Read status is captured up front so the order doesn't shift while
+	// items are being marked
+	isRead := make(map[string]bool, len(items))
+	for _, item := range items {
+		isRead[item.Key()] = m.storage.IsRead(item.Key())
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch m.sortMode {
+		case SortOldestFirst:
+			return m.indexes[a.Key()] > m.indexes[b.Key()]
+		case SortUnreadFirst:
+			if isRead[a.Key()] != isRead[b.Key()] {
+				return !isRead[a.Key()]
+			}
+		case SortByFeed:
+			if a.FeedName != b.FeedName {
+				return a.FeedName < b.FeedName
+			}
+		}
+		return m.indexes[a.Key()] < m.indexes[b.Key()]
+	})
+
+	cursor := 0
+	if m.cursor < len(m.items) {
+		key := m.items[m.cursor].Key()
+		for i, item := range items {
+			if item.Key() == key {
+				cursor = i
+				break
+			}
+		}
+	}
+
+	m.items = items
+	m.cursor = cursor
+	m.scrollOffset = 0
+	m.adjustScroll()
+}
+
+// toggleRead marks an item as read or unread
+func (m *Model) toggleRead(key string) tea.Cmd {
+	if m.storage.IsRead(key) {
+		if err := m.storage.MarkAsUnread(key); err != nil {
+			return m.showErrorToast(err)
+		}
+		return m.showToast(i18n.T("Marked as unread"))
+	}
+	if err := m.storage.MarkAsRead(key); err != nil {
+		return m.showErrorToast(err)
+	}
+	return m.showToast(i18n.T("Marked as read"))
+}
+
+// markAllRead marks every listed item as read
+func (m *Model) markAllRead() tea.Cmd {
+	var keys []string
+	for _, item := range m.items {
+		if !m.storage.IsRead(item.Key()) {
+			keys = append(keys, item.Key())
+		}
+	}
+	if len(keys) == 0 {
+		return m.showToast(i18n.T("No unread items"))
+	}
+	if err := m.storage.MarkAllAsRead(keys); err != nil {
+		return m.showErrorToast(err)
+	}
+	return m.showToast(i18n.T("Marked %d items read", len(keys)))
+}
+
+// toggleStar stars or unstars an item
+func (m *Model) toggleStar(key string) tea.Cmd {
+	starred := !m.storage.IsStarred(key)
+	if err := m.storage.SetStarred(key, starred); err != nil {
+		return m.showErrorToast(err)
+	}
+	if starred {
+		return m.showToast(i18n.T("Starred"))
+	}
+	return m.showToast(i18n.T("Unstarred"))
+}
+
+// toggleLater adds an item to the read-later queue or removes it
+func (m *Model) toggleLater(key string) tea.Cmd {
+	later := !m.storage.IsLater(key)
+	if err := m.storage.SetLater(key, later); err != nil {
+		return m.showErrorToast(err)
+	}
+	if later {
+		return m.showToast(i18n.T("Queued to read later"))
+	}
+	return m.showToast(i18n.T("Removed from read later"))
+}
+
+// snoozeDuration is how long the TUI snoozes an item for
+const snoozeDuration = 24 * time.Hour
+
+// toggleSnooze snoozes an item for snoozeDuration, or ends its snooze
+func (m *Model) toggleSnooze(key string) tea.Cmd {
+	until := time.Time{}
+	if !m.storage.IsSnoozed(key) {
+		until = time.Now().Add(snoozeDuration)
+	}
+	if err := m.storage.Snooze(key, until); err != nil {
+		return m.showErrorToast(err)
+	}
+	if until.IsZero() {
+		return m.showToast(i18n.T("Snooze ended"))
+	}
+	return m.showToast(i18n.T("Snoozed until %s", FormatTime(until, "2006-01-02 15:04")))
 }
 
 // adjustScroll adjusts scroll offset to keep cursor visible
 func (m *Model) adjustScroll() {
-	visibleHeight := m.height - 4 // Account for header and status
+	visibleHeight := m.height - m.headerLines() - 1 // Account for header and status
 
 	if m.cursor < m.scrollOffset {
 		m.scrollOffset = m.cursor
@@ -182,19 +522,25 @@ func (m *Model) adjustScroll() {
 // View renders the current view
 func (m Model) View() string {
 	if m.width == 0 {
-		return "Loading..."
+		return i18n.T("Loading...")
 	}
 
+	var view string
 	switch m.viewMode {
 	case ViewList:
-		return m.renderListView()
+		view = m.renderListView()
 	case ViewReader:
-		return m.renderReaderView()
-	case ViewHelp:
-		return m.renderHelpView()
+		view = m.renderReaderView()
+	case ViewErrors:
+		view = m.renderErrorsView()
 	default:
-		return "Unknown view"
+		return i18n.T("Unknown view")
+	}
+
+	if m.showHelp {
+		return overlay(view, m.renderHelpView(), m.width, m.height)
 	}
+	return view
 }
 
 // renderListView renders the list of news items
@@ -202,22 +548,36 @@ func (m Model) renderListView() string {
 	var b strings.Builder
 
 	// Header
-	header := headerStyle.Render("Informant - Arch Linux News Reader")
+	header := headerStyle.Render(i18n.T("Informant - Arch Linux News Reader"))
 	b.WriteString(header + "\n")
 
 	// Status line
 	unreadCount := 0
 	for _, item := range m.items {
-		if !m.storage.IsRead(item.ID) {
+		if m.isPending(item.Key()) {
 			unreadCount++
 		}
 	}
 
-	status := fmt.Sprintf("Items: %d | Unread: %d | Use ? for help", len(m.items), unreadCount)
-	b.WriteString(statusStyle.Render(status) + "\n\n")
+	status := i18n.T("Items: %d | Unread: %d | Sort: %s", len(m.items), unreadCount, m.sortMode)
+	if m.starredOnly {
+		status += " | " + i18n.T("Starred only")
+	}
+	if len(m.feedErrors) > 0 {
+		status += " | " + i18n.T("%d feeds failed, e to show", len(m.feedErrors))
+	}
+	status += " | " + i18n.T("Use ? for help")
+	if m.notice != "" {
+		status += " | " + m.notice
+	}
+	b.WriteString(statusStyle.Render(status) + "\n")
+	if summary := m.feedSummary(); summary != "" {
+		b.WriteString(statusStyle.Render(summary) + "\n")
+	}
+	b.WriteString("\n")
 
 	// Items list
-	visibleHeight := m.height - 6 // Account for header, status, and help
+	visibleHeight := m.height - m.headerLines() - 3 // Account for header, status, and help
 	start := m.scrollOffset
 	end := start + visibleHeight
 
@@ -225,37 +585,27 @@ func (m Model) renderListView() string {
 		end = len(m.items)
 	}
 
+	widths := columnWidths(m.items, m.columns, m.width-4)
+
 	for i := start; i < end; i++ {
 		item := m.items[i]
 		isSelected := (i == m.cursor)
-		isRead := m.storage.IsRead(item.ID)
-
-		// Format item line
-		status := "●"
-		if isRead {
-			status = "○"
-		}
-
-		// Format date
-		dateStr := item.Published.Format("2006-01-02")
-
-		feedInfo := ""
-		if item.FeedName != "" {
-			feedInfo = fmt.Sprintf(" (%s)", item.FeedName)
-		}
-
-		line := fmt.Sprintf("%s %s %s%s", status, dateStr, item.Title, feedInfo)
+		isRead := m.storage.IsRead(item.Key())
 
-		// Truncate if too long
-		maxWidth := m.width - 4
-		if len(line) > maxWidth {
-			line = line[:maxWidth-3] + "..."
-		}
+		line := renderColumns(row{
+			item:      item,
+			index:     m.indexes[item.Key()],
+			isRead:    isRead,
+			isStarred: m.storage.IsStarred(item.Key()),
+			isLater:   m.storage.IsLater(item.Key()),
+			isNew:     m.newItems[item.Key()] && !isRead,
+		}, m.columns, widths)
 
 		// Apply style
-		style := GetItemStyle(isSelected, isRead)
+		// Snoozed items are dimmed like read ones until the snooze expires
+		style := GetItemStyle(isSelected, isRead || m.storage.IsSnoozed(item.Key()), item.Highlighted)
 		if isSelected {
-			line = "▶ " + line
+			line = glyphs.cursor + " " + line
 		} else {
 			line = "  " + line
 		}
@@ -271,13 +621,17 @@ func (m Model) renderListView() string {
 
 	// Error display
 	if m.err != nil {
-		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n" + errorStyle.Render(i18n.T("Error: %v", m.err)))
 		// Clear error after displaying it once
 		m.err = nil
 	}
 
+	if toast := m.renderToast(); toast != "" {
+		b.WriteString("\n" + toast)
+	}
+
 	// Help hint
-	b.WriteString("\n" + helpStyle.Render("Press ? for help, q to quit"))
+	b.WriteString("\n" + helpStyle.Render(i18n.T("Press ? for help, q to quit")))
 
 	return b.String()
 }
@@ -285,35 +639,37 @@ func (m Model) renderListView() string {
 // renderReaderView renders the content of a selected item
 func (m Model) renderReaderView() string {
 	if m.selectedItem == nil {
-		return errorStyle.Render("No item selected")
+		return errorStyle.Render(i18n.T("No item selected"))
 	}
 
 	var b strings.Builder
 
 	// Header with title
-	title := fmt.Sprintf("Reading: %s", m.selectedItem.Title)
+	title := i18n.T("Reading: %s", m.selectedItem.Title)
+	if m.width > 4 {
+		title = runewidth.Truncate(title, m.width-4, "...")
+	}
 	header := contentHeaderStyle.Render(title)
 	b.WriteString(header + "\n")
 
 	// Meta information
-	dateStr := m.selectedItem.Published.Format("2006-01-02 15:04:05")
-	meta := dateStyle.Render("Date: " + dateStr)
+	dateStr := FormatTime(m.selectedItem.Published, "2006-01-02 15:04:05")
+	meta := dateStyle.Render(i18n.T("Date:") + " " + dateStr)
 
 	if m.selectedItem.FeedName != "" {
-		meta += " | " + feedNameStyle.Render("Feed: "+m.selectedItem.FeedName)
+		meta += " | " + feedNameStyle.Render(i18n.T("Feed:")+" "+m.selectedItem.FeedName)
 	}
 
-	readStatus := "Unread"
-	if m.storage.IsRead(m.selectedItem.ID) {
-		readStatus = "Read"
+	readStatus := i18n.T("Unread")
+	if m.storage.IsRead(m.selectedItem.Key()) {
+		readStatus = i18n.T("Read")
 	}
-	meta += " | Status: " + readStatus
+	meta += " | " + i18n.T("Status:") + " " + readStatus
 
 	b.WriteString(meta + "\n\n")
 
 	// Content with scroll
-	content := m.selectedItem.Content
-	lines := strings.Split(content, "\n")
+	lines := m.contentLines
 
 	visibleHeight := m.height - 8 // Account for header, meta, and controls
 	start := m.scrollOffset
@@ -330,36 +686,51 @@ func (m Model) renderReaderView() string {
 
 	// Scroll indicator
 	if len(lines) > visibleHeight {
-		scrollInfo := fmt.Sprintf("[Line %d-%d of %d]", start+1, end, len(lines))
+		scrollInfo := i18n.T("[Line %d-%d of %d]", start+1, end, len(lines))
 		b.WriteString("\n" + statusStyle.Render(scrollInfo))
 	}
 
 	// Error display
 	if m.err != nil {
-		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n" + errorStyle.Render(i18n.T("Error: %v", m.err)))
 		// Clear error after displaying it once
 		m.err = nil
 	}
 
+	if toast := m.renderToast(); toast != "" {
+		b.WriteString("\n" + toast)
+	}
+
 	// Controls
-	b.WriteString("\n" + helpStyle.Render("j/k: scroll | r: toggle read | q: back to list"))
+	b.WriteString("\n" + helpStyle.Render(i18n.T("j/k: scroll | r: toggle read | q: back to list")))
 
 	return b.String()
 }
 
-// renderHelpView renders the help screen
+// renderHelpView renders the help box shown over the current view
 func (m Model) renderHelpView() string {
 	var b strings.Builder
 
-	header := contentHeaderStyle.Render("Informant Help")
+	header := contentHeaderStyle.Render(i18n.T("Informant Help"))
 	b.WriteString(header + "\n\n")
 
 	helpText := [][]string{
 		{"Navigation", ""},
-		{"j, ↓", "Move down"},
-		{"k, ↑", "Move up"},
-		{"g", "Go to first item"},
-		{"G", "Go to last item"},
+		{"j, " + glyphs.down, "Move down"},
+		{"k, " + glyphs.up, "Move up"},
+		{"g, Home", "Go to first item"},
+		{"G, End", "Go to last item"},
+		{"PgDn, PgUp", "Move a page down/up"},
+		{"C-d, C-u", "Move half a page down/up"},
+		{"n, Tab", "Next unread item"},
+		{"p, S-Tab", "Previous unread item"},
+		{"s", "Cycle sort order"},
+		{"b", "Star/unstar item"},
+		{"B", "Show only starred items"},
+		{"A", "Mark all listed items as read"},
+		{"e", "Show feed errors"},
+		{"z", "Snooze item for a day"},
+		{"l", "Add to/remove from read later"},
 		{"", ""},
 		{"Actions", ""},
 		{"Enter", "Read selected item"},
@@ -368,10 +739,21 @@ func (m Model) renderHelpView() string {
 		{"q", "Quit application"},
 		{"", ""},
 		{"Reader Mode", ""},
-		{"j, ↓", "Scroll content down"},
-		{"k, ↑", "Scroll content up"},
+		{"j, " + glyphs.down, "Scroll content down"},
+		{"k, " + glyphs.up, "Scroll content up"},
+		{"PgDn, PgUp", "Scroll a page down/up"},
+		{"C-d, C-u", "Scroll half a page down/up"},
+		{"g, Home", "Go to top"},
+		{"G, End", "Go to bottom"},
 		{"r", "Toggle read status"},
+		{"b", "Star/unstar item"},
+		{"z", "Snooze item for a day"},
+		{"l", "Add to/remove from read later"},
 		{"q, Esc", "Back to list"},
+		{"", ""},
+		{"Feed Errors", ""},
+		{"r, Enter", "Retry selected feed"},
+		{"q, Esc, e", "Back to list"},
 	}
 
 	for _, row := range helpText {
@@ -382,17 +764,17 @@ func (m Model) renderHelpView() string {
 
 		if row[1] == "" {
 			// Section header
-			b.WriteString(titleStyle.Render(row[0]) + "\n")
+			b.WriteString(titleStyle.Render(i18n.T(row[0])) + "\n")
 		} else {
 			// Key binding
 			key := helpKeyStyle.Render(row[0])
-			desc := helpStyle.Render(row[1])
+			desc := helpStyle.Render(i18n.T(row[1]))
 			line := fmt.Sprintf("  %-12s %s", key, desc)
 			b.WriteString(line + "\n")
 		}
 	}
 
-	b.WriteString("\n" + helpStyle.Render("Press ? or q to close help"))
+	b.WriteString("\n" + helpStyle.Render(i18n.T("Press any key to close help")))
 
-	return contentStyle.Width(m.width - 4).Render(b.String())
+	return contentStyle.Render(b.String())
 }
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// ansiPattern matches the escape sequences used for styling
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+// overlay draws box centered over background, which is dimmed so the box
+// stands out while the underlying view stays visible
+func overlay(background, box string, width, height int) string {
+	lines := strings.Split(ansiPattern.ReplaceAllString(background, ""), "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+
+	top := (len(lines) - len(boxLines)) / 2
+	if top < 0 {
+		top = 0
+	}
+	left := (width - boxWidth) / 2
+	if left < 0 {
+		left = 0
+	}
+
+	for i, line := range lines {
+		if i < top || i >= top+len(boxLines) {
+			lines[i] = dimmedStyle.Render(line)
+			continue
+		}
+
+		// Cut by display width, so wide characters keep the box aligned
+		leftPart := runewidth.FillRight(runewidth.Truncate(line, left, ""), left)
+
+		boxLine := boxLines[i-top]
+		if pad := boxWidth - lipgloss.Width(boxLine); pad > 0 {
+			boxLine += strings.Repeat(" ", pad)
+		}
+
+		right := runewidth.TruncateLeft(line, left+boxWidth, "")
+
+		lines[i] = dimmedStyle.Render(leftPart) + boxLine + dimmedStyle.Render(right)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,42 @@
+package tui
+
+// listPageSize is the number of items the list view shows at once
+func (m Model) listPageSize() int {
+	return max(m.height-m.headerLines()-3, 1)
+}
+
+// readerPageSize is the number of content lines the reader shows at once
+func (m Model) readerPageSize() int {
+	return max(m.height-8, 1)
+}
+
+// moveCursor moves the list cursor by delta items, stopping at the first
+// and last item
+func (m *Model) moveCursor(delta int) {
+	if len(m.items) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.items)-1)
+	m.adjustScroll()
+}
+
+// scrollContent scrolls the reader by delta lines, stopping at the top and
+// where the last line comes into view
+func (m *Model) scrollContent(delta int) {
+	last := max(len(m.contentLines)-m.readerPageSize(), 0)
+	m.scrollOffset = min(max(m.scrollOffset+delta, 0), last)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// resize adapts the views to a new terminal size. The reader content is
+// wrapped again for the new width and stays at about the same place, and
+// the list keeps the cursor in view.
+func (m *Model) resize(width, height int) {
+	// Where the reader was, as a share of the content
+	position := 0.0
+	if len(m.contentLines) > 0 {
+		position = float64(m.scrollOffset) / float64(len(m.contentLines))
+	}
+
+	m.width = width
+	m.height = height
+
+	switch m.viewMode {
+	case ViewReader:
+		m.renderContent()
+		m.scrollOffset = int(position*float64(len(m.contentLines)) + 0.5)
+		m.scrollContent(0)
+	case ViewList:
+		m.adjustScroll()
+	}
+}
+
+// wrapText word-wraps text to width columns, breaking words longer than a
+// line. Existing line breaks are kept.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		if width <= 0 || runewidth.StringWidth(paragraph) <= width {
+			lines = append(lines, paragraph)
+			continue
+		}
+
+		line, lineWidth := "", 0
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := runewidth.StringWidth(word)
+			if lineWidth > 0 && lineWidth+1+wordWidth > width {
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+			}
+			for wordWidth > width {
+				// Words wider than a line are split wherever they reach the edge
+				head := runewidth.Truncate(word, width-lineWidth, "")
+				if head == "" {
+					break
+				}
+				lines = append(lines, line+head)
+				word = strings.TrimPrefix(word, head)
+				wordWidth = runewidth.StringWidth(word)
+				line, lineWidth = "", 0
+			}
+			if lineWidth > 0 {
+				line += " "
+				lineWidth++
+			}
+			line += word
+			lineWidth += wordWidth
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
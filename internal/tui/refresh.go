@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"informant/internal/i18n"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// refreshTickMsg asks for the feeds to be fetched again
+type refreshTickMsg struct{}
+
+// refreshedMsg carries the items of a periodic refresh
+type refreshedMsg struct {
+	items ItemsMsg
+}
+
+// SetAutoRefresh makes the TUI fetch the feeds again with refresh every
+// interval. Items arriving with a refresh are marked as new.
+func (m *Model) SetAutoRefresh(interval time.Duration, refresh func() ItemsMsg) {
+	m.refreshInterval = interval
+	m.refresh = refresh
+}
+
+// scheduleRefresh returns the command waiting for the next refresh, nil
+// without auto-refresh
+func (m Model) scheduleRefresh() tea.Cmd {
+	if m.refreshInterval <= 0 || m.refresh == nil {
+		return nil
+	}
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+// applyRefresh shows the refreshed items, marking those not listed before as
+// new, and announces them or feeds that started failing in a toast
+func (m *Model) applyRefresh(msg ItemsMsg) tea.Cmd {
+	failing := make(map[string]bool, len(m.feedErrors))
+	for _, feedErr := range m.feedErrors {
+		failing[feedErr.URL] = true
+	}
+
+	known := make(map[string]bool, len(m.allItems))
+	for _, item := range m.allItems {
+		known[item.Key()] = true
+	}
+
+	arrived := 0
+	for _, item := range msg.Items {
+		if !known[item.Key()] {
+			if m.newItems == nil {
+				m.newItems = make(map[string]bool)
+			}
+			m.newItems[item.Key()] = true
+			arrived++
+		}
+	}
+
+	m.applyItems(msg)
+
+	cmds := []tea.Cmd{m.scheduleRefresh()}
+	for _, feedErr := range m.feedErrors {
+		if !failing[feedErr.URL] {
+			name := feedErr.Name
+			if name == "" {
+				name = feedErr.URL
+			}
+			cmds = append(cmds, m.setToast(i18n.T("Feed refresh failed: %s: %s", name, feedErr.Error), true))
+			return tea.Batch(cmds...)
+		}
+	}
+	if arrived > 0 {
+		cmds = append(cmds, m.showToast(i18n.T("%d new items", arrived)))
+	}
+	return tea.Batch(cmds...)
+}
@@ -11,6 +11,7 @@ var (
 	accentColor    = lipgloss.Color("10")  // Green
 	warningColor   = lipgloss.Color("11")  // Yellow
 	errorColor     = lipgloss.Color("9")   // Red
+	highlightColor = lipgloss.Color("13")  // Magenta
 
 	// Header styles
 	headerStyle = lipgloss.NewStyle().
@@ -42,6 +43,17 @@ var (
 				Bold(true).
 				Padding(0, 1)
 
+	highlightedItemStyle = lipgloss.NewStyle().
+				Foreground(highlightColor).
+				Bold(true).
+				Padding(0, 1)
+
+	selectedHighlightedItemStyle = lipgloss.NewStyle().
+					Background(highlightColor).
+					Foreground(lipgloss.Color("0")).
+					Bold(true).
+					Padding(0, 1)
+
 	readItemStyle = lipgloss.NewStyle().
 			Foreground(secondaryColor).
 			Padding(0, 1)
@@ -78,6 +90,11 @@ var (
 			Foreground(primaryColor).
 			Bold(true)
 
+	// Background dimmed behind overlays
+	dimmedStyle = lipgloss.NewStyle().
+			Foreground(secondaryColor).
+			Faint(true)
+
 	// Status styles
 	statusStyle = lipgloss.NewStyle().
 			Foreground(secondaryColor).
@@ -108,9 +125,28 @@ var (
 				Background(secondaryColor)
 )
 
-// GetItemStyle returns the appropriate style for a list item
-func GetItemStyle(isSelected, isRead bool) lipgloss.Style {
+// Styles shared with the command line output
+var (
+	// CLIUnreadStyle marks unread item titles
+	CLIUnreadStyle = lipgloss.NewStyle().Bold(true)
+	// CLIDateStyle dims dates
+	CLIDateStyle = dateStyle
+	// CLIFeedNameStyle colors feed names
+	CLIFeedNameStyle = lipgloss.NewStyle().Foreground(accentColor)
+	// CLIHighlightStyle marks items matching a highlight rule
+	CLIHighlightStyle = lipgloss.NewStyle().Foreground(highlightColor).Bold(true)
+	// CLILabelStyle styles field labels such as "Title:"
+	CLILabelStyle = titleStyle
+)
+
+// GetItemStyle returns the appropriate style for a list item. Unread
+// highlighted items stand out from other unread items.
+func GetItemStyle(isSelected, isRead, isHighlighted bool) lipgloss.Style {
 	switch {
+	case isSelected && !isRead && isHighlighted:
+		return selectedHighlightedItemStyle
+	case !isSelected && !isRead && isHighlighted:
+		return highlightedItemStyle
 	case isSelected && !isRead:
 		return selectedUnreadItemStyle
 	case isSelected && isRead:
@@ -0,0 +1,21 @@
+package tui
+
+import "time"
+
+// displayLocation is the timezone timestamps are shown in, nil to keep the
+// one they were parsed with
+var displayLocation *time.Location
+
+// SetTimezone converts displayed timestamps to loc, or leaves them as the
+// feed gave them when loc is nil
+func SetTimezone(loc *time.Location) {
+	displayLocation = loc
+}
+
+// FormatTime formats a timestamp in the configured display timezone
+func FormatTime(t time.Time, layout string) string {
+	if displayLocation != nil {
+		t = t.In(displayLocation)
+	}
+	return t.Format(layout)
+}
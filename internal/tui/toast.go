@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"informant/internal/i18n"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toastDuration is how long a toast stays on screen
+const toastDuration = 4 * time.Second
+
+// clearToastMsg hides the toast with the same id, unless a newer one
+// replaced it
+type clearToastMsg struct {
+	id int
+}
+
+// showToast shows a short message about the outcome of an action and
+// returns the command that hides it again
+func (m *Model) showToast(text string) tea.Cmd {
+	return m.setToast(text, false)
+}
+
+// showErrorToast shows a failed action as a toast
+func (m *Model) showErrorToast(err error) tea.Cmd {
+	return m.setToast(i18n.T("Error: %v", err), true)
+}
+
+// setToast shows a toast, styled as an error with isError
+func (m *Model) setToast(text string, isError bool) tea.Cmd {
+	m.toast = text
+	m.toastIsError = isError
+	m.toastID++
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return clearToastMsg{id: id}
+	})
+}
+
+// renderToast returns the toast line, empty when there is no toast
+func (m Model) renderToast() string {
+	if m.toast == "" {
+		return ""
+	}
+	if m.toastIsError {
+		return errorStyle.Render(m.toast)
+	}
+	return statusStyle.Render(m.toast)
+}
@@ -0,0 +1,119 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"informant/internal/feed"
+)
+
+// ReleaseURL is the GitHub API endpoint describing the latest release
+const ReleaseURL = "https://api.github.com/repos/vhqtvn/informant-go/releases/latest"
+
+// CheckInterval is how often the latest release is looked up at most
+const CheckInterval = 24 * time.Hour
+
+// requestTimeout keeps a slow API from delaying the command
+const requestTimeout = 5 * time.Second
+
+// release is the part of the GitHub release document that is used
+type release struct {
+	TagName string `json:"tag_name"`
+}
+
+// Latest returns the version of the latest release, without a leading "v".
+// The answer is kept in the cache for CheckInterval, and so are failed
+// lookups, so the API is asked at most once a day.
+func Latest(ctx context.Context, cache feed.CacheStorage) (string, error) {
+	body, found := cache.GetCacheFile(ReleaseURL, CheckInterval)
+	if !found {
+		var err error
+		body, err = fetchRelease(ctx)
+		if err != nil {
+			// Remember the failure so the next attempt waits a day too
+			cache.SetCacheFile(ReleaseURL, []byte("{}"))
+			return "", err
+		}
+		if err := cache.SetCacheFile(ReleaseURL, body); err != nil {
+			return "", fmt.Errorf("failed to cache release: %w", err)
+		}
+	}
+
+	var latest release
+	if err := json.Unmarshal(body, &latest); err != nil {
+		return "", fmt.Errorf("failed to parse release: %w", err)
+	}
+	return strings.TrimPrefix(latest.TagName, "v"), nil
+}
+
+// fetchRelease downloads the latest release document
+func fetchRelease(ctx context.Context) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ReleaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release: %w", err)
+	}
+	return body, nil
+}
+
+// Newer reports whether version a is newer than version b. Versions are
+// compared by their dot-separated numbers; anything after a "-" or "+" is
+// ignored.
+func Newer(a, b string) bool {
+	pa, pb := parts(a), parts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+// parts splits a version like "v1.4.1-rc1" into its numbers
+func parts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	var numbers []int
+	for _, field := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
@@ -0,0 +1,41 @@
+// Package plugin is the public API for extending informant from Go: fetchers
+// for URL schemes, parsers for feed formats and processors for the items of
+// every fetched feed. Plugins register themselves from an init function of a
+// package imported by the informant binary.
+package plugin
+
+import "informant/internal/feed"
+
+// Item is a news item as parsed from a feed
+type Item = feed.Item
+
+// Fetcher retrieves the raw feed data for URLs of a scheme
+type Fetcher = feed.Fetcher
+
+// FetcherFunc adapts a function to the Fetcher interface
+type FetcherFunc = feed.FetcherFunc
+
+// Parser decodes feed data of one format
+type Parser = feed.Parser
+
+// Processor post-processes the items of every fetched feed, for example to
+// rewrite or drop them
+type Processor = feed.Processor
+
+// RegisterFetcher makes url fetches with the given scheme, such as "https"
+// or "exec", use f, replacing any fetcher registered for it before
+func RegisterFetcher(scheme string, f Fetcher) {
+	feed.RegisterFetcher(scheme, f)
+}
+
+// RegisterParser adds a feed format. Formats are detected in the order
+// they were registered, and a parser with the same name is replaced.
+func RegisterParser(p Parser) {
+	feed.RegisterParser(p)
+}
+
+// RegisterProcessor adds a post-processing step, run in the order of
+// registration. A processor with the same name is replaced.
+func RegisterProcessor(p Processor) {
+	feed.RegisterProcessor(p)
+}